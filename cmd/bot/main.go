@@ -0,0 +1,245 @@
+// cmd/bot/main.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Standalone bot client that connects to an RPS server over
+//          TCP and plays using one of the built-in automated
+//          strategies, for solo play or strategy benchmarking.
+// **************************************************************
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"rps_game/internal/rps"
+)
+
+func main() {
+	hostname := flag.String("host", "localhost", "server hostname")
+	port := flag.String("port", "", "server port")
+	nickname := flag.String("nickname", "", "bot nickname (default: randomly generated)")
+	strategy := flag.String("strategy", "random", "bot strategy: random, frequency, markov")
+	window := flag.Int("window", 10, "opponent history window size for the frequency strategy")
+	order := flag.Int("order", 1, "markov chain order (1 or 2) for the markov strategy")
+	flag.Parse()
+
+	if *port == "" {
+		fmt.Println("Usage: bot -port <port> [-host <host>] [-nickname <name>] [-strategy random|frequency|markov]")
+		os.Exit(1)
+	}
+
+	name := *nickname
+	if name == "" {
+		name = fmt.Sprintf("bot-%d", os.Getpid())
+	}
+
+	if err := run(*hostname, *port, *strategy, name, *window, *order); err != nil {
+		log.Fatalf("bot exited with error: %v", err)
+	}
+}
+
+// newBot constructs the rps.Player for the requested strategy name,
+// playing ruleSet.
+func newBot(strategy, nickname string, window, order int, ruleSet rps.RuleSet) (rps.Player, error) {
+	switch strategy {
+	case "random":
+		return rps.NewRandomBot(nickname, ruleSet), nil
+	case "frequency":
+		return rps.NewFrequencyBot(nickname, window, ruleSet), nil
+	case "markov":
+		return rps.NewMarkovBot(nickname, order, ruleSet), nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", strategy)
+	}
+}
+
+// run connects to the server, completes the handshake, learns the
+// active rule set, and drives a bot of the requested strategy through
+// a full game.
+func run(hostname, port, strategy, nickname string, window, order int) error {
+	address := net.JoinHostPort(hostname, port)
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server at %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	key, err := receiveHello(conn)
+	if err != nil {
+		return err
+	}
+
+	if err := negotiateNickname(conn, key, nickname); err != nil {
+		return err
+	}
+
+	ruleSet, err := receiveRuleSet(conn, key)
+	if err != nil {
+		return err
+	}
+
+	bot, err := newBot(strategy, nickname, window, order, ruleSet)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%s connected and ready to play (rules: %s)", bot.Nickname(), ruleSet.Name)
+	return gameLoop(conn, key, bot)
+}
+
+// receiveHello reads the server's HELLO frame and returns the session
+// key it carries.
+func receiveHello(conn net.Conn) ([]byte, error) {
+	msgType, payload, err := rps.ReadFrame(conn, rps.HelloKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive hello: %w", err)
+	}
+	if msgType != rps.MsgHello {
+		return nil, fmt.Errorf("unexpected message before hello: %s", msgType)
+	}
+
+	var hello rps.HelloPayload
+	if err := json.Unmarshal(payload, &hello); err != nil {
+		return nil, fmt.Errorf("failed to decode hello payload: %w", err)
+	}
+	return hello.Key, nil
+}
+
+// negotiateNickname offers nickname to the server, appending a suffix
+// and retrying if it is already taken.
+func negotiateNickname(conn net.Conn, key []byte, nickname string) error {
+	for {
+		if err := rps.WriteFrame(conn, key, rps.MsgNickname, rps.NicknamePayload{Nickname: nickname}); err != nil {
+			return fmt.Errorf("failed to send nickname: %w", err)
+		}
+
+		msgType, payload, err := rps.ReadFrame(conn, key)
+		if err != nil {
+			return fmt.Errorf("failed to receive nickname response: %w", err)
+		}
+		if msgType != rps.MsgNickname {
+			return fmt.Errorf("unexpected message type %s while confirming nickname", msgType)
+		}
+
+		var resp rps.NicknamePayload
+		if err := json.Unmarshal(payload, &resp); err != nil {
+			return fmt.Errorf("failed to decode nickname response: %w", err)
+		}
+		if !resp.Retry {
+			return nil
+		}
+		nickname = fmt.Sprintf("%s-%d", nickname, os.Getpid())
+	}
+}
+
+// receiveRuleSet reads the RULE_SET frame the server sends right after
+// nickname acceptance.
+func receiveRuleSet(conn net.Conn, key []byte) (rps.RuleSet, error) {
+	msgType, payload, err := readGameFrame(conn, key)
+	if err != nil {
+		return rps.RuleSet{}, fmt.Errorf("failed to receive rule set: %w", err)
+	}
+	if msgType != rps.MsgRuleSet {
+		return rps.RuleSet{}, fmt.Errorf("unexpected message type %s while receiving rule set", msgType)
+	}
+
+	var ruleSet rps.RuleSetPayload
+	if err := json.Unmarshal(payload, &ruleSet); err != nil {
+		return rps.RuleSet{}, fmt.Errorf("failed to decode rule set payload: %w", err)
+	}
+	return rps.RuleSet{Name: ruleSet.Name, Moves: ruleSet.Moves, Beats: ruleSet.Beats}, nil
+}
+
+// readGameFrame reads the next frame relevant to gameplay, answering
+// any keepalive PING the server sends while a match is being found
+// with a PONG and continuing to read rather than surfacing it to the
+// caller.
+func readGameFrame(conn net.Conn, key []byte) (rps.MessageType, []byte, error) {
+	for {
+		msgType, payload, err := rps.ReadFrame(conn, key)
+		if err != nil {
+			return 0, nil, err
+		}
+		if msgType != rps.MsgPing {
+			return msgType, payload, nil
+		}
+		if err := rps.WriteFrame(conn, key, rps.MsgPong, struct{}{}); err != nil {
+			return 0, nil, fmt.Errorf("failed to send pong: %w", err)
+		}
+	}
+}
+
+// gameLoop drives bot's choices until the server reports a final
+// score or closes the connection. round tags each CHOICE frame so the
+// server can tell a late answer to a previous round apart from this
+// one (see ConnPlayer.Choose).
+func gameLoop(conn net.Conn, key []byte, bot rps.Player) error {
+	ctx := context.Background()
+	round := 0
+	for {
+		choice, err := bot.Choose(ctx, rps.RoundState{})
+		if err != nil {
+			return fmt.Errorf("bot failed to choose: %w", err)
+		}
+		if err := rps.WriteFrame(conn, key, rps.MsgChoice, rps.ChoicePayload{Choice: choice, Round: round}); err != nil {
+			return fmt.Errorf("failed to send choice: %w", err)
+		}
+
+		msgType, payload, err := readGameFrame(conn, key)
+		if err != nil {
+			return fmt.Errorf("failed to receive game response: %w", err)
+		}
+
+		switch msgType {
+		case rps.MsgFinalScore:
+			var final rps.FinalScorePayload
+			if err := json.Unmarshal(payload, &final); err != nil {
+				return fmt.Errorf("failed to decode final score: %w", err)
+			}
+			log.Printf("%s finished %d - %d", bot.Nickname(), final.Score, final.OpponentScore)
+			return nil
+
+		case rps.MsgRoundResult:
+			var result rps.RoundResultPayload
+			if err := json.Unmarshal(payload, &result); err != nil {
+				return fmt.Errorf("failed to decode round result: %w", err)
+			}
+			bot.Notify(rps.RoundOutcome{
+				OwnChoice:        choice,
+				OpponentChoice:   result.OpponentChoice,
+				OpponentNickname: result.OpponentNickname,
+				Outcome:          result.Outcome,
+				RoundsLeft:       result.RoundsLeft,
+			})
+			round++
+
+		case rps.MsgTimeout:
+			var timeout rps.TimeoutPayload
+			if err := json.Unmarshal(payload, &timeout); err != nil {
+				return fmt.Errorf("failed to decode timeout payload: %w", err)
+			}
+			if timeout.MatchForfeited {
+				log.Printf("%s: %s failed to choose in time, match forfeited", bot.Nickname(), timeout.OpponentNickname)
+				return nil
+			}
+			log.Printf("%s: %s failed to choose in time, round forfeited", bot.Nickname(), timeout.OpponentNickname)
+			round++
+
+		case rps.MsgDisconnect:
+			var reason rps.DisconnectPayload
+			if err := json.Unmarshal(payload, &reason); err != nil {
+				return fmt.Errorf("failed to decode disconnect payload: %w", err)
+			}
+			return fmt.Errorf("server closed the connection: %s", reason.Reason)
+
+		default:
+			return fmt.Errorf("unexpected message type %s during game loop", msgType)
+		}
+	}
+}
@@ -10,6 +10,7 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -22,8 +23,14 @@ import (
 // Client represents the RPS client.
 type Client struct {
 	Conn     net.Conn
+	Key      []byte
 	Hostname string
 	Port     string
+
+	// Moves is the active rule set's move list, learned from the
+	// server's RULE_SET frame and used by promptChoice to validate
+	// input instead of assuming classic rock/paper/scissors.
+	Moves []string
 }
 
 // NewClient initializes a new RPS client.
@@ -58,18 +65,18 @@ func (c *Client) Start() error {
 
 	reader := bufio.NewReader(os.Stdin)
 
-	// Receive initial READY message from server
-	initialMsg, err := rps.ReceiveMessage(c.Conn)
-	if err != nil {
-		return fmt.Errorf("failed to receive initial message: %w", err)
-	}
-	if initialMsg != "READY" {
-		return fmt.Errorf("unexpected initial message: %s", initialMsg)
+	// Receive the HELLO frame carrying our session key
+	if err := c.receiveHello(); err != nil {
+		return err
 	}
 
 	// Prompt for nickname
-	nickname, err := c.promptNickname(reader)
-	if err != nil {
+	if _, err := c.promptNickname(reader); err != nil {
+		return err
+	}
+
+	// Learn the active rule set so promptChoice knows what moves to offer
+	if err := c.receiveRuleSet(); err != nil {
 		return err
 	}
 
@@ -82,6 +89,25 @@ func (c *Client) Start() error {
 	return nil
 }
 
+// receiveHello reads the server's HELLO frame and stores the session
+// key used to authenticate every later frame on this connection.
+func (c *Client) receiveHello() error {
+	msgType, payload, err := rps.ReadFrame(c.Conn, rps.HelloKey())
+	if err != nil {
+		return fmt.Errorf("failed to receive hello: %w", err)
+	}
+	if msgType != rps.MsgHello {
+		return fmt.Errorf("unexpected message before hello: %s", msgType)
+	}
+
+	var hello rps.HelloPayload
+	if err := json.Unmarshal(payload, &hello); err != nil {
+		return fmt.Errorf("failed to decode hello payload: %w", err)
+	}
+	c.Key = hello.Key
+	return nil
+}
+
 // promptNickname prompts the user to enter a unique nickname and sends it to the server.
 func (c *Client) promptNickname(reader *bufio.Reader) (string, error) {
 	for {
@@ -97,31 +123,59 @@ func (c *Client) promptNickname(reader *bufio.Reader) (string, error) {
 		}
 
 		// Send nickname to server
-		if err := rps.SendMessage(c.Conn, nickname); err != nil {
+		if err := rps.WriteFrame(c.Conn, c.Key, rps.MsgNickname, rps.NicknamePayload{Nickname: nickname}); err != nil {
 			return "", fmt.Errorf("failed to send nickname: %w", err)
 		}
 
 		// Wait for server response
-		response, err := rps.ReceiveMessage(c.Conn)
+		msgType, payload, err := rps.ReadFrame(c.Conn, c.Key)
 		if err != nil {
 			return "", fmt.Errorf("failed to receive nickname response: %w", err)
 		}
+		if msgType != rps.MsgNickname {
+			return "", fmt.Errorf("unexpected message type %s while confirming nickname", msgType)
+		}
 
-		if response == "RETRY" {
+		var resp rps.NicknamePayload
+		if err := json.Unmarshal(payload, &resp); err != nil {
+			return "", fmt.Errorf("failed to decode nickname response: %w", err)
+		}
+
+		if resp.Retry {
 			fmt.Println("Nickname is not unique. Please choose another one.")
 			continue
-		} else if response == "START" {
-			fmt.Println("Nickname accepted. Game is starting...")
-			return nickname, nil
-		} else {
-			fmt.Printf("Unexpected server response: %s\n", response)
-			continue
 		}
+
+		fmt.Println("Nickname accepted. Game is starting...")
+		return nickname, nil
+	}
+}
+
+// receiveRuleSet reads the RULE_SET frame the server sends right after
+// nickname acceptance and stores its move list for promptChoice.
+func (c *Client) receiveRuleSet() error {
+	msgType, payload, err := c.readGameFrame()
+	if err != nil {
+		return fmt.Errorf("failed to receive rule set: %w", err)
+	}
+	if msgType != rps.MsgRuleSet {
+		return fmt.Errorf("unexpected message type %s while receiving rule set", msgType)
 	}
+
+	var ruleSet rps.RuleSetPayload
+	if err := json.Unmarshal(payload, &ruleSet); err != nil {
+		return fmt.Errorf("failed to decode rule set payload: %w", err)
+	}
+	c.Moves = ruleSet.Moves
+	fmt.Printf("Rules: %s (%s)\n", ruleSet.Name, strings.Join(ruleSet.Moves, ", "))
+	return nil
 }
 
-// gameLoop handles the main game interaction with the server.
+// gameLoop handles the main game interaction with the server. round
+// tags each CHOICE frame so the server can tell a late answer to a
+// previous round apart from this one (see ConnPlayer.Choose).
 func (c *Client) gameLoop(reader *bufio.Reader) error {
+	round := 0
 	for {
 		// Prompt for choice
 		choice, err := c.promptChoice(reader)
@@ -130,52 +184,102 @@ func (c *Client) gameLoop(reader *bufio.Reader) error {
 		}
 
 		// Send choice to server
-		if err := rps.SendMessage(c.Conn, choice); err != nil {
+		if err := rps.WriteFrame(c.Conn, c.Key, rps.MsgChoice, rps.ChoicePayload{Choice: choice, Round: round}); err != nil {
 			return fmt.Errorf("failed to send choice: %w", err)
 		}
 
 		// Receive response from server
-		response, err := rps.ReceiveMessage(c.Conn)
+		msgType, payload, err := c.readGameFrame()
 		if err != nil {
 			return fmt.Errorf("failed to receive game response: %w", err)
 		}
 
-		if strings.HasPrefix(response, "SCORE") {
-			// Final score received
-			fmt.Println("Final Score:", response)
-			break
-		}
+		switch msgType {
+		case rps.MsgFinalScore:
+			var final rps.FinalScorePayload
+			if err := json.Unmarshal(payload, &final); err != nil {
+				return fmt.Errorf("failed to decode final score: %w", err)
+			}
+			fmt.Printf("Final Score: %d - %d\n", final.Score, final.OpponentScore)
+			return nil
 
-		// Parse round result
-		parts := strings.Split(response, " ")
-		if len(parts) != 4 {
-			fmt.Printf("Malformed round result: %s\n", response)
-			continue
-		}
+		case rps.MsgRoundResult:
+			var result rps.RoundResultPayload
+			if err := json.Unmarshal(payload, &result); err != nil {
+				return fmt.Errorf("failed to decode round result: %w", err)
+			}
+			fmt.Printf("\n%s's choice: %s\nOutcome: %s\nRounds left: %d\n\n",
+				result.OpponentNickname, result.OpponentChoice, result.Outcome, result.RoundsLeft)
+			round++
 
-		enemyChoice, outcome, roundsLeft, enemyNickname := parts[0], parts[1], parts[2], parts[3]
-		fmt.Printf("\n%s's choice: %s\nOutcome: %s\nRounds left: %s\n\n", enemyNickname, enemyChoice, outcome, roundsLeft)
+		case rps.MsgTimeout:
+			var timeout rps.TimeoutPayload
+			if err := json.Unmarshal(payload, &timeout); err != nil {
+				return fmt.Errorf("failed to decode timeout payload: %w", err)
+			}
+			if timeout.MatchForfeited {
+				fmt.Printf("\n%s failed to choose in time. Match forfeited.\n", timeout.OpponentNickname)
+				return nil
+			}
+			fmt.Printf("\n%s failed to choose in time. Round forfeited.\n\n", timeout.OpponentNickname)
+			round++
 
-		if roundsLeft == "0" {
-			break
+		case rps.MsgDisconnect:
+			var reason rps.DisconnectPayload
+			if err := json.Unmarshal(payload, &reason); err != nil {
+				return fmt.Errorf("failed to decode disconnect payload: %w", err)
+			}
+			return fmt.Errorf("server closed the connection: %s", reason.Reason)
+
+		default:
+			return fmt.Errorf("unexpected message type %s during game loop", msgType)
 		}
 	}
-	return nil
 }
 
-// promptChoice prompts the user to choose rock, paper, or scissors.
+// readGameFrame reads the next frame relevant to gameplay, answering
+// any keepalive PING the server sends while a match is being found
+// with a PONG and continuing to read rather than surfacing it to the
+// caller.
+func (c *Client) readGameFrame() (rps.MessageType, []byte, error) {
+	for {
+		msgType, payload, err := rps.ReadFrame(c.Conn, c.Key)
+		if err != nil {
+			return 0, nil, err
+		}
+		if msgType != rps.MsgPing {
+			return msgType, payload, nil
+		}
+		if err := rps.WriteFrame(c.Conn, c.Key, rps.MsgPong, struct{}{}); err != nil {
+			return 0, nil, fmt.Errorf("failed to send pong: %w", err)
+		}
+	}
+}
+
+// promptChoice prompts the user to choose one of the active rule set's
+// moves.
 func (c *Client) promptChoice(reader *bufio.Reader) (string, error) {
 	for {
-		fmt.Print("Choose ('rock', 'paper', 'scissors'): ")
+		fmt.Printf("Choose (%s): ", strings.Join(c.Moves, ", "))
 		choice, err := reader.ReadString('\n')
 		if err != nil {
 			return "", fmt.Errorf("failed to read choice: %w", err)
 		}
 		choice = strings.TrimSpace(strings.ToLower(choice))
-		if choice != "rock" && choice != "paper" && choice != "scissors" {
-			fmt.Println("Invalid choice. Please enter 'rock', 'paper', or 'scissors'.")
+		if !contains(c.Moves, choice) {
+			fmt.Printf("Invalid choice. Please enter one of: %s.\n", strings.Join(c.Moves, ", "))
 			continue
 		}
 		return choice, nil
 	}
 }
+
+// contains reports whether s appears in list.
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -8,43 +8,85 @@
 package main
 
 import (
-	"context"
+	"flag"
 	"fmt"
 	"log"
-	"net"
+	"net/http"
 	"os"
 	"strconv"
-	"sync"
+	"time"
 
 	"rps_game/internal/rps"
+	"rps_game/internal/rps/scorekeeper"
 )
 
-// NewGameManager creates a new GameManager with specified rounds.
-func NewGameManager(rounds int) *rps.GameManager {
-	ctx, cancel := context.WithCancel(context.Background())
-	return &rps.GameManager{
-		WaitingPlayers: make(chan net.Conn, 100), // buffer for 100 waiting players
-		Rounds:         rounds,
-		wg:             sync.WaitGroup{},
-		ctx:            ctx,
-		cancel:         cancel,
-	}
-}
-
 func main() {
-	if len(os.Args) < 3 {
-		fmt.Printf("Usage: %s <number_of_rounds> <port>\n", os.Args[0])
+	matchmakingTimeout := flag.Duration("matchmaking-timeout", 0,
+		"fill an empty matchmaking slot with a bot after this long waiting for a second player (0 disables bot fill-in)")
+	storePath := flag.String("store", "rps_scores.db", "path to the SQLite database used to record games")
+	httpPort := flag.String("http-port", "", "port to serve /leaderboard, /player/{nick}/history, and /game/{id} on (disabled if empty)")
+	turnTimeout := flag.Duration("turn-timeout", 30*time.Second,
+		"how long to wait for a player's choice each round before forfeiting (0 disables the deadline)")
+	forfeitMatchOnTimeout := flag.Bool("forfeit-match-on-timeout", false,
+		"end the whole match on a turn timeout instead of only forfeiting the round")
+	pingInterval := flag.Duration("ping-interval", 15*time.Second,
+		"how often to ping a player waiting for an opponent, to evict dead connections (0 disables keepalive pings)")
+	adminPort := flag.String("admin-port", "", "port to serve a RESP (Redis protocol) admin/spectator surface on (disabled if empty)")
+	rules := flag.String("rules", "classic", `rule set to play with: "classic", "rps5", "rps101", or a path to a custom JSON rule set file`)
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 2 {
+		fmt.Printf("Usage: %s [flags] <number_of_rounds> <port>\n", os.Args[0])
 		os.Exit(1)
 	}
 
-	rounds, err := strconv.Atoi(os.Args[1])
+	rounds, err := strconv.Atoi(args[0])
 	if err != nil || rounds <= 0 {
 		log.Fatalf("Invalid number_of_rounds: %v\n", err)
 	}
-	port := os.Args[2]
+	port := args[1]
+
+	store, err := scorekeeper.NewSQLiteStore(*storePath)
+	if err != nil {
+		log.Fatalf("Failed to open score store: %v\n", err)
+	}
+	sk := scorekeeper.New(store)
+	defer sk.Close()
+
+	if *httpPort != "" {
+		go func() {
+			log.Printf("Leaderboard HTTP server listening on port %s", *httpPort)
+			if err := http.ListenAndServe(":"+*httpPort, scorekeeper.NewHTTPHandler(sk.Store())); err != nil {
+				log.Printf("Leaderboard HTTP server error: %v\n", err)
+			}
+		}()
+	}
+
+	ruleSet, err := rps.LoadRuleSet(*rules)
+	if err != nil {
+		log.Fatalf("Failed to load rule set: %v\n", err)
+	}
 
 	// Initialize the server
 	server := rps.NewServer(rounds, port)
+	server.RuleSet = ruleSet
+	server.MatchmakingTimeout = *matchmakingTimeout
+	server.ScoreKeeper = sk
+	server.TurnTimeout = *turnTimeout
+	server.ForfeitMatchOnTimeout = *forfeitMatchOnTimeout
+	server.PingInterval = *pingInterval
+
+	if *adminPort != "" {
+		server.Events = rps.NewEventBroadcaster()
+		admin := rps.NewAdminServer(server)
+		go func() {
+			log.Printf("RESP admin server listening on port %s", *adminPort)
+			if err := admin.ListenAndServe(":" + *adminPort); err != nil {
+				log.Printf("RESP admin server error: %v\n", err)
+			}
+		}()
+	}
 
 	// Start the server
 	if err := server.Start(); err != nil {
@@ -0,0 +1,169 @@
+// internal/rps/admin.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Exposes a RESP-protocol admin/spectator surface over the
+//          Server's in-memory state, so any redis-cli can query
+//          active games and players, kick idle connections, and
+//          subscribe to live round results.
+// **************************************************************
+
+package rps
+
+import (
+	"fmt"
+
+	"rps_game/internal/rps/resp"
+)
+
+// NewAdminServer returns a resp.Server wired to s's in-memory state:
+//
+//	GAMES                - one summary line per in-progress game
+//	PLAYERS              - every nickname currently reserved
+//	KICK <nickname>       - forcibly disconnect a player
+//	SUBSCRIBE <channel>   - stream round results from one game's
+//	                        "games/<id>" channel
+//	PSUBSCRIBE <pattern>  - stream round results from every channel
+//	                        matching pattern, e.g. "games/*"
+//
+// Only a single channel or pattern per SUBSCRIBE/PSUBSCRIBE call is
+// supported, which is enough for a redis-cli to spectate one game, or
+// every game, without reimplementing the custom framed protocol.
+func NewAdminServer(s *Server) *resp.Server {
+	rs := resp.NewServer()
+	rs.Handle("GAMES", s.handleGames)
+	rs.Handle("PLAYERS", s.handlePlayers)
+	rs.Handle("KICK", s.handleKick)
+	rs.Handle("SUBSCRIBE", s.handleSubscribe)
+	rs.Handle("PSUBSCRIBE", s.handlePSubscribe)
+	return rs
+}
+
+// handleGames lists every in-progress game as one summary line.
+func (s *Server) handleGames(w *resp.Writer, cmd resp.Command) {
+	s.gamesMu.Lock()
+	lines := make([]string, 0, len(s.games))
+	for _, g := range s.games {
+		lines = append(lines, fmt.Sprintf("%s: %s vs %s (%d-%d), round %d/%d", g.ID, g.Player1, g.Player2, g.Score1, g.Score2, g.Round+1, g.TotalRounds))
+	}
+	s.gamesMu.Unlock()
+	w.WriteStringArray(lines)
+}
+
+// handlePlayers lists every nickname currently reserved, whether
+// waiting for an opponent or already in a game.
+func (s *Server) handlePlayers(w *resp.Writer, cmd resp.Command) {
+	s.mu.Lock()
+	names := make([]string, 0, len(s.nicknames))
+	for nick := range s.nicknames {
+		names = append(names, nick)
+	}
+	s.mu.Unlock()
+	w.WriteStringArray(names)
+}
+
+// handleKick forcibly closes a nickname's connection.
+func (s *Server) handleKick(w *resp.Writer, cmd resp.Command) {
+	if len(cmd.Args) != 1 {
+		w.WriteError("ERR usage: KICK <nickname>")
+		return
+	}
+	if !s.kickConn(cmd.Args[0]) {
+		w.WriteError("ERR no such player")
+		return
+	}
+	w.WriteSimpleString("OK")
+}
+
+// handleSubscribe streams round results from a single "games/<id>"
+// channel.
+func (s *Server) handleSubscribe(w *resp.Writer, cmd resp.Command) {
+	if len(cmd.Args) != 1 {
+		w.WriteError("ERR usage: SUBSCRIBE <channel>")
+		return
+	}
+	s.streamChannel(w, cmd.Args[0], false)
+}
+
+// handlePSubscribe streams round results from every channel matching
+// a glob pattern, e.g. "games/*".
+func (s *Server) handlePSubscribe(w *resp.Writer, cmd resp.Command) {
+	if len(cmd.Args) != 1 {
+		w.WriteError("ERR usage: PSUBSCRIBE <pattern>")
+		return
+	}
+	s.streamChannel(w, cmd.Args[0], true)
+}
+
+// streamChannel subscribes to name (a literal channel, or a pattern
+// when pattern is true) on s.Events and forwards every published
+// message to w as a Redis pub/sub "message"/"pmessage" push. It blocks
+// for the life of the connection, as real Redis pub/sub connections
+// do, returning once w errors (the client disconnected).
+func (s *Server) streamChannel(w *resp.Writer, name string, pattern bool) {
+	if s.Events == nil {
+		w.WriteError("ERR event broadcasting is disabled")
+		return
+	}
+
+	var msgs <-chan EventMessage
+	var cancel func()
+	if pattern {
+		msgs, cancel = s.Events.SubscribePattern(name)
+	} else {
+		msgs, cancel = s.Events.Subscribe(name)
+	}
+	defer cancel()
+
+	kind := "subscribe"
+	if pattern {
+		kind = "psubscribe"
+	}
+	w.WriteArrayHeader(3)
+	w.WriteBulkString(kind)
+	w.WriteBulkString(name)
+	w.WriteInteger(1)
+
+	for msg := range msgs {
+		var err error
+		if pattern {
+			err = writePMessage(w, name, msg.Channel, msg.Payload)
+		} else {
+			err = writeMessage(w, msg.Channel, msg.Payload)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeMessage writes a Redis pub/sub "message" push: [message, channel, payload].
+func writeMessage(w *resp.Writer, channel string, payload []byte) error {
+	if err := w.WriteArrayHeader(3); err != nil {
+		return err
+	}
+	if err := w.WriteBulkString("message"); err != nil {
+		return err
+	}
+	if err := w.WriteBulkString(channel); err != nil {
+		return err
+	}
+	return w.WriteBulkString(string(payload))
+}
+
+// writePMessage writes a Redis pub/sub "pmessage" push:
+// [pmessage, pattern, channel, payload].
+func writePMessage(w *resp.Writer, pattern, channel string, payload []byte) error {
+	if err := w.WriteArrayHeader(4); err != nil {
+		return err
+	}
+	if err := w.WriteBulkString("pmessage"); err != nil {
+		return err
+	}
+	if err := w.WriteBulkString(pattern); err != nil {
+		return err
+	}
+	if err := w.WriteBulkString(channel); err != nil {
+		return err
+	}
+	return w.WriteBulkString(string(payload))
+}
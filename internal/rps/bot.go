@@ -0,0 +1,190 @@
+// internal/rps/bot.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Built-in automated Player implementations: a uniform
+//          random bot, a frequency-counting bot, and a Markov-chain
+//          bot conditioned on the opponent's recent choices. All
+//          three play against the active RuleSet instead of assuming
+//          classic rock/paper/scissors.
+// **************************************************************
+
+package rps
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// randomMove returns a uniformly random move from ruleSet.
+func randomMove(rng *rand.Rand, ruleSet RuleSet) string {
+	return ruleSet.Moves[rng.Intn(len(ruleSet.Moves))]
+}
+
+// counterMove returns a move from ruleSet that beats move, chosen
+// uniformly at random among ties (a RuleSet like RPS5 has more than
+// one move beating a given move). It falls back to a random move if
+// move is undefeated or not recognized by ruleSet.
+func counterMove(rng *rand.Rand, ruleSet RuleSet, move string) string {
+	winners := ruleSet.Counters(move)
+	if len(winners) == 0 {
+		return randomMove(rng, ruleSet)
+	}
+	return winners[rng.Intn(len(winners))]
+}
+
+// RandomBot chooses uniformly at random among its RuleSet's moves
+// every round.
+type RandomBot struct {
+	nickname string
+	ruleSet  RuleSet
+	rng      *rand.Rand
+}
+
+// NewRandomBot creates a RandomBot with the given nickname, playing
+// ruleSet.
+func NewRandomBot(nickname string, ruleSet RuleSet) *RandomBot {
+	return &RandomBot{nickname: nickname, ruleSet: ruleSet, rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// Nickname returns the bot's nickname.
+func (b *RandomBot) Nickname() string { return b.nickname }
+
+// Choose returns a uniformly random move.
+func (b *RandomBot) Choose(ctx context.Context, state RoundState) (string, error) {
+	return randomMove(b.rng, b.ruleSet), nil
+}
+
+// Notify is a no-op; the random bot does not adapt to results.
+func (b *RandomBot) Notify(outcome RoundOutcome) {}
+
+// FrequencyBot tracks the opponent's last `window` choices and plays a
+// move that beats whichever choice the opponent has made most often
+// in that window.
+type FrequencyBot struct {
+	nickname string
+	ruleSet  RuleSet
+	window   int
+	history  []string
+	rng      *rand.Rand
+}
+
+// NewFrequencyBot creates a FrequencyBot that remembers the
+// opponent's last window choices and plays ruleSet. A non-positive
+// window is treated as 1.
+func NewFrequencyBot(nickname string, window int, ruleSet RuleSet) *FrequencyBot {
+	if window <= 0 {
+		window = 1
+	}
+	return &FrequencyBot{
+		nickname: nickname,
+		ruleSet:  ruleSet,
+		window:   window,
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Nickname returns the bot's nickname.
+func (b *FrequencyBot) Nickname() string { return b.nickname }
+
+// Choose counters the opponent's most frequent recent move, falling
+// back to a random move until any history has been observed.
+func (b *FrequencyBot) Choose(ctx context.Context, state RoundState) (string, error) {
+	if len(b.history) == 0 {
+		return randomMove(b.rng, b.ruleSet), nil
+	}
+
+	counts := make(map[string]int, len(b.ruleSet.Moves))
+	for _, m := range b.history {
+		counts[m]++
+	}
+
+	mostCommon, best := b.ruleSet.Moves[0], -1
+	for _, m := range b.ruleSet.Moves {
+		if counts[m] > best {
+			best = counts[m]
+			mostCommon = m
+		}
+	}
+	return counterMove(b.rng, b.ruleSet, mostCommon), nil
+}
+
+// Notify records the opponent's choice, trimming the history back to
+// the configured window.
+func (b *FrequencyBot) Notify(outcome RoundOutcome) {
+	b.history = append(b.history, outcome.OpponentChoice)
+	if len(b.history) > b.window {
+		b.history = b.history[len(b.history)-b.window:]
+	}
+}
+
+// MarkovBot predicts the opponent's next move from a transition table
+// keyed on the opponent's last `order` choices (1 or 2), and plays a
+// move that beats the most likely prediction.
+type MarkovBot struct {
+	nickname string
+	ruleSet  RuleSet
+	order    int
+	history  []string
+	table    map[string]map[string]int
+	rng      *rand.Rand
+}
+
+// NewMarkovBot creates a MarkovBot conditioned on the opponent's last
+// order choices, playing ruleSet. order must be 1 or 2; any other
+// value is treated as 1.
+func NewMarkovBot(nickname string, order int, ruleSet RuleSet) *MarkovBot {
+	if order != 1 && order != 2 {
+		order = 1
+	}
+	return &MarkovBot{
+		nickname: nickname,
+		ruleSet:  ruleSet,
+		order:    order,
+		table:    make(map[string]map[string]int),
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Nickname returns the bot's nickname.
+func (b *MarkovBot) Nickname() string { return b.nickname }
+
+// Choose counters the move most often seen following the current
+// context, falling back to a random move for contexts never observed
+// before.
+func (b *MarkovBot) Choose(ctx context.Context, state RoundState) (string, error) {
+	dist, ok := b.table[b.contextKey()]
+	if !ok || len(dist) == 0 {
+		return randomMove(b.rng, b.ruleSet), nil
+	}
+
+	predicted, best := b.ruleSet.Moves[0], -1
+	for _, m := range b.ruleSet.Moves {
+		if dist[m] > best {
+			best = dist[m]
+			predicted = m
+		}
+	}
+	return counterMove(b.rng, b.ruleSet, predicted), nil
+}
+
+// Notify records the opponent's choice against the context that
+// preceded it, then advances the context by one round.
+func (b *MarkovBot) Notify(outcome RoundOutcome) {
+	key := b.contextKey()
+	if _, ok := b.table[key]; !ok {
+		b.table[key] = make(map[string]int)
+	}
+	b.table[key][outcome.OpponentChoice]++
+
+	b.history = append(b.history, outcome.OpponentChoice)
+	if len(b.history) > b.order {
+		b.history = b.history[len(b.history)-b.order:]
+	}
+}
+
+// contextKey encodes the current history window as a map key.
+func (b *MarkovBot) contextKey() string {
+	return strings.Join(b.history, ",")
+}
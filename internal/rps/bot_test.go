@@ -0,0 +1,79 @@
+// internal/rps/bot_test.go
+package rps
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFrequencyBotCountersMostCommonChoice(t *testing.T) {
+	bot := NewFrequencyBot("freq", 3, ClassicRuleSet)
+	for i := 0; i < 3; i++ {
+		bot.Notify(RoundOutcome{OpponentChoice: "rock"})
+	}
+
+	choice, err := bot.Choose(context.Background(), RoundState{})
+	if err != nil {
+		t.Fatalf("Choose() error = %v", err)
+	}
+	if want := "paper"; choice != want {
+		t.Errorf("Choose() = %q; want %q", choice, want)
+	}
+}
+
+func TestFrequencyBotWindowTrimsHistory(t *testing.T) {
+	bot := NewFrequencyBot("freq", 2, ClassicRuleSet)
+	bot.Notify(RoundOutcome{OpponentChoice: "rock"})
+	bot.Notify(RoundOutcome{OpponentChoice: "paper"})
+	bot.Notify(RoundOutcome{OpponentChoice: "paper"})
+
+	if len(bot.history) != 2 {
+		t.Fatalf("history length = %d; want 2", len(bot.history))
+	}
+	for _, m := range bot.history {
+		if m != "paper" {
+			t.Errorf("history = %v; want only the most recent 2 entries", bot.history)
+		}
+	}
+}
+
+func TestMarkovBotCountersPredictedChoice(t *testing.T) {
+	bot := NewMarkovBot("markov", 1, ClassicRuleSet)
+
+	// The opponent has once followed "rock" with "paper"; the last
+	// choice in history is "rock" again, so the bot should predict
+	// "paper" next and counter it.
+	bot.Notify(RoundOutcome{OpponentChoice: "rock"})
+	bot.Notify(RoundOutcome{OpponentChoice: "paper"})
+	bot.Notify(RoundOutcome{OpponentChoice: "rock"})
+
+	choice, err := bot.Choose(context.Background(), RoundState{})
+	if err != nil {
+		t.Fatalf("Choose() error = %v", err)
+	}
+	if want := "scissors"; choice != want {
+		t.Errorf("Choose() = %q; want %q", choice, want)
+	}
+}
+
+func TestFrequencyBotCountersAgainstMultiWinnerRuleSet(t *testing.T) {
+	bot := NewFrequencyBot("freq", 3, RPS5RuleSet)
+	for i := 0; i < 3; i++ {
+		bot.Notify(RoundOutcome{OpponentChoice: "rock"})
+	}
+
+	choice, err := bot.Choose(context.Background(), RoundState{})
+	if err != nil {
+		t.Fatalf("Choose() error = %v", err)
+	}
+	winners := RPS5RuleSet.Counters("rock")
+	found := false
+	for _, w := range winners {
+		if choice == w {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Choose() = %q; want one of %v", choice, winners)
+	}
+}
@@ -0,0 +1,112 @@
+// internal/rps/broadcaster.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Fans out live game events (round results, final scores) to
+//          subscribers, backing the RESP admin surface's SUBSCRIBE
+//          and PSUBSCRIBE commands.
+// **************************************************************
+
+package rps
+
+import (
+	"path"
+	"sync"
+)
+
+// EventMessage is one event published to a channel, e.g. "games/<id>".
+type EventMessage struct {
+	Channel string
+	Payload []byte
+}
+
+// subscriberQueueSize bounds how many undelivered events a slow
+// subscriber can have buffered before Publish starts dropping events
+// for it rather than blocking the game loop.
+const subscriberQueueSize = 16
+
+// EventBroadcaster fans out published events to every Subscribe and
+// SubscribePattern listener whose channel or pattern matches. The zero
+// value is not usable; use NewEventBroadcaster.
+type EventBroadcaster struct {
+	mu       sync.Mutex
+	subs     map[string]map[chan EventMessage]bool
+	patterns map[string]map[chan EventMessage]bool
+}
+
+// NewEventBroadcaster returns a ready-to-use EventBroadcaster.
+func NewEventBroadcaster() *EventBroadcaster {
+	return &EventBroadcaster{
+		subs:     make(map[string]map[chan EventMessage]bool),
+		patterns: make(map[string]map[chan EventMessage]bool),
+	}
+}
+
+// Subscribe returns a channel of events published to channel, and a
+// cancel function that stops delivery and releases the channel. Call
+// cancel once the subscriber disconnects.
+func (b *EventBroadcaster) Subscribe(channel string) (<-chan EventMessage, func()) {
+	ch := make(chan EventMessage, subscriberQueueSize)
+
+	b.mu.Lock()
+	if b.subs[channel] == nil {
+		b.subs[channel] = make(map[chan EventMessage]bool)
+	}
+	b.subs[channel][ch] = true
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subs[channel], ch)
+		b.mu.Unlock()
+		close(ch)
+	}
+}
+
+// SubscribePattern is like Subscribe, but matches any channel against
+// pattern using path.Match-style globbing (e.g. "games/*").
+func (b *EventBroadcaster) SubscribePattern(pattern string) (<-chan EventMessage, func()) {
+	ch := make(chan EventMessage, subscriberQueueSize)
+
+	b.mu.Lock()
+	if b.patterns[pattern] == nil {
+		b.patterns[pattern] = make(map[chan EventMessage]bool)
+	}
+	b.patterns[pattern][ch] = true
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.patterns[pattern], ch)
+		b.mu.Unlock()
+		close(ch)
+	}
+}
+
+// Publish fans payload out to every exact-channel subscriber of
+// channel and every pattern subscriber whose pattern matches it. A
+// subscriber whose buffer is full is skipped rather than blocking the
+// publisher.
+func (b *EventBroadcaster) Publish(channel string, payload []byte) {
+	msg := EventMessage{Channel: channel, Payload: payload}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs[channel] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+	for pattern, chans := range b.patterns {
+		if ok, _ := path.Match(pattern, channel); !ok {
+			continue
+		}
+		for ch := range chans {
+			select {
+			case ch <- msg:
+			default:
+			}
+		}
+	}
+}
@@ -8,178 +8,550 @@
 package rps
 
 import (
-	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"net"
 	"os"
-	"strings"
+	"os/signal"
 	"sync"
+	"time"
+
+	"rps_game/internal/rps/scorekeeper"
 )
 
-// InvalidHeaderError is returned when the message does not start with the correct header.
-type InvalidHeaderError struct {
-	Received string
+// Game represents a game between two players.
+type Game struct {
+	Player1 Player
+	Player2 Player
+	Rounds  int
 }
 
-func (e *InvalidHeaderError) Error() string {
-	return fmt.Sprintf("invalid header: expected ⚠, got %s", e.Received)
+// NewServer initializes a new RPS server.
+func NewServer(rounds int, port string) *Server {
+	return &Server{
+		Rounds:         rounds,
+		Port:           port,
+		WaitingPlayers: make(chan Player, 100), // buffer for 100 waiting players
+		Quit:           make(chan os.Signal, 1),
+		RuleSet:        ClassicRuleSet,
+		nicknames:      make(map[string]bool),
+		connByNickname: make(map[string]net.Conn),
+		games:          make(map[string]*gameInfo),
+	}
 }
 
-// InvalidFooterError is returned when the message does not end with the correct footer.
-type InvalidFooterError struct {
-	Received string
+// Server represents the RPS server.
+type Server struct {
+	Rounds         int
+	Port           string
+	WaitingPlayers chan Player
+	Quit           chan os.Signal
+
+	// MatchmakingTimeout, when positive, bounds how long a waiting
+	// player sits alone before the matchmaker pairs them with a
+	// RandomBot instead of a second human. Zero disables bot fill-in.
+	MatchmakingTimeout time.Duration
+
+	// ScoreKeeper, when set, receives every completed game for
+	// persistent storage. A nil ScoreKeeper simply skips publishing.
+	ScoreKeeper *scorekeeper.Scorekeeper
+
+	// TurnTimeout, when positive, bounds how long the game loop waits
+	// for a player's choice each round. A player that misses the
+	// deadline forfeits the round (or the match, see
+	// ForfeitMatchOnTimeout) and the opponent is sent a TIMEOUT
+	// message. Zero disables the deadline.
+	TurnTimeout time.Duration
+
+	// ForfeitMatchOnTimeout, when true, ends the whole match the first
+	// time a player misses TurnTimeout instead of only forfeiting the
+	// round they timed out on.
+	ForfeitMatchOnTimeout bool
+
+	// PingInterval, when positive, is how often a PING frame is sent
+	// to a player sitting in WaitingPlayers. A write failure closes
+	// the connection; the player itself stays queued in WaitingPlayers
+	// until the matchmaker pairs it, and that game is then aborted at
+	// its first failed read. Zero disables keepalive pings.
+	PingInterval time.Duration
+
+	// Events, when set, receives a GameEvent after every round and
+	// once more when a game ends, published on that game's
+	// "games/<id>" channel. A nil Events simply skips publishing; the
+	// RESP admin server's SUBSCRIBE/PSUBSCRIBE commands read from it.
+	Events *EventBroadcaster
+
+	// RuleSet determines which moves are valid and which beats which.
+	// NewServer defaults it to ClassicRuleSet; cmd/server/main.go
+	// overwrites it with the result of LoadRuleSet when -rules names a
+	// different built-in or a custom rule set file. It is sent to each
+	// player as a RULE_SET frame right after their nickname is accepted.
+	RuleSet RuleSet
+
+	wg             sync.WaitGroup
+	mu             sync.Mutex
+	nicknames      map[string]bool
+	connByNickname map[string]net.Conn
+
+	gamesMu sync.Mutex
+	games   map[string]*gameInfo
 }
 
-func (e *InvalidFooterError) Error() string {
-	return fmt.Sprintf("invalid footer: expected ☠, got %s", e.Received)
+// gameInfo is the in-memory record of an in-progress game, queried by
+// the RESP admin server's GAMES command and updated by runGame as
+// rounds complete.
+type gameInfo struct {
+	ID          string
+	Player1     string
+	Player2     string
+	Score1      int
+	Score2      int
+	Round       int
+	TotalRounds int
 }
 
-// Encrypt shifts each character in the message by 3 positions.
-// This simple encryption ensures basic message security.
-func Encrypt(msg string) string {
-	var encrypted strings.Builder
-	for _, char := range msg {
-		encrypted.WriteRune(char + 3)
-	}
-	return encrypted.String()
+// GameEvent is the JSON payload published to a game's "games/<id>"
+// channel after every round, and once more with Final set when the
+// match ends.
+type GameEvent struct {
+	GameID        string `json:"game_id"`
+	Round         int    `json:"round"`
+	Player1       string `json:"player1"`
+	Player2       string `json:"player2"`
+	Player1Choice string `json:"player1_choice,omitempty"`
+	Player2Choice string `json:"player2_choice,omitempty"`
+	Score1        int    `json:"score1"`
+	Score2        int    `json:"score2"`
+	Final         bool   `json:"final,omitempty"`
 }
 
-// Decrypt shifts each character in the message by -3 positions.
-// It reverses the encryption applied to the message.
-func Decrypt(msg string) string {
-	var decrypted strings.Builder
-	for _, char := range msg {
-		decrypted.WriteRune(char - 3)
+// Start listens on the server's port, accepts connections, and pairs
+// waiting players into games. It blocks until the server is asked to
+// quit via s.Quit, at which point it waits for in-flight games to
+// finish before returning.
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", ":"+s.Port)
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %s: %w", s.Port, err)
+	}
+	defer listener.Close()
+
+	signal.Notify(s.Quit, os.Interrupt)
+	quitting := make(chan struct{})
+	go func() {
+		<-s.Quit
+		close(quitting)
+		listener.Close()
+	}()
+
+	go s.matchmaker()
+
+	log.Printf("RPS server listening on port %s", s.Port)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-quitting:
+				s.wg.Wait()
+				return nil
+			default:
+				log.Printf("accept error: %v", err)
+				continue
+			}
+		}
+
+		s.wg.Add(1)
+		go s.handleConnection(conn)
 	}
-	return decrypted.String()
 }
 
-// SendMessage sends a message with a header and footer over the connection.
-// It encrypts the message before sending to ensure basic security.
-func SendMessage(conn net.Conn, msg string) error {
-	header := "\u26A0" // ⚠ Warning Sign
-	footer := "\u2620" // ☠ Skull and Crossbones
-	encryptedMsg := Encrypt(msg)
-	fullMsg := fmt.Sprintf("%s%s%s\n", header, encryptedMsg, footer)
+// handleConnection negotiates a session key and nickname with a newly
+// accepted connection, then queues the resulting Player for
+// matchmaking.
+func (s *Server) handleConnection(conn net.Conn) {
+	defer s.wg.Done()
 
-	writer := bufio.NewWriter(conn)
-	_, err := writer.WriteString(fullMsg)
-	if err != nil {
-		return fmt.Errorf("failed to write message: %w", err)
+	key := make([]byte, tagSize)
+	if _, err := rand.Read(key); err != nil {
+		log.Printf("failed to generate session key: %v", err)
+		conn.Close()
+		return
+	}
+	if err := WriteFrame(conn, HelloKey(), MsgHello, HelloPayload{Key: key}); err != nil {
+		log.Printf("hello failed: %v", err)
+		conn.Close()
+		return
 	}
 
-	err = writer.Flush()
+	nickname, err := s.negotiateNickname(conn, key)
 	if err != nil {
-		return fmt.Errorf("failed to flush message: %w", err)
+		log.Printf("nickname exchange failed: %v", err)
+		conn.Close()
+		return
 	}
 
-	return nil
+	player := NewConnPlayer(conn, key, nickname)
+	go s.pingWhileWaiting(player)
+	s.WaitingPlayers <- player
 }
 
-// ReceiveMessage reads, validates, and decrypts a message from the connection.
-// It ensures that the message contains the correct header and footer.
-func ReceiveMessage(conn net.Conn) (string, error) {
-	reader := bufio.NewReader(conn)
-	msg, err := reader.ReadString('\n')
-	if err != nil {
-		return "", fmt.Errorf("failed to read message: %w", err)
+// pingWhileWaiting periodically sends a PING frame to a player sitting
+// in WaitingPlayers so a dead TCP connection is detected and closed.
+// Closing does not remove the player from WaitingPlayers. It returns
+// once the player is dequeued into a game, signalled via
+// player.stopPing.
+func (s *Server) pingWhileWaiting(player *ConnPlayer) {
+	if s.PingInterval <= 0 {
+		return
 	}
 
-	msg = strings.TrimSpace(msg)
+	ticker := time.NewTicker(s.PingInterval)
+	defer ticker.Stop()
 
-	if len(msg) < 2 {
-		return "", errors.New("message too short to contain header and footer")
+	for {
+		select {
+		case <-player.stopPing:
+			return
+		case <-ticker.C:
+			if err := WriteFrame(player.Conn, player.Key, MsgPing, struct{}{}); err != nil {
+				log.Printf("keepalive ping failed for %s, evicting dead connection: %v", player.nickname, err)
+				player.Conn.Close()
+				return
+			}
+		}
 	}
+}
 
-	header := msg[:1]
-	footer := msg[len(msg)-1:]
-	content := msg[1 : len(msg)-1]
+// negotiateNickname reads NICKNAME frames from conn until the client
+// offers one that is not already taken, reserving it for the
+// connection's lifetime.
+func (s *Server) negotiateNickname(conn net.Conn, key []byte) (string, error) {
+	for {
+		msgType, payload, err := ReadFrame(conn, key)
+		if err != nil {
+			return "", err
+		}
+		if msgType != MsgNickname {
+			return "", fmt.Errorf("expected NICKNAME frame, got %s", msgType)
+		}
 
-	if header != "\u26A0" { // ⚠
-		return "", &InvalidHeaderError{Received: header}
-	}
+		var req NicknamePayload
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return "", fmt.Errorf("failed to decode nickname payload: %w", err)
+		}
+		if req.Nickname == "" {
+			continue
+		}
 
-	if footer != "\u2620" { // ☠
-		return "", &InvalidFooterError{Received: footer}
-	}
+		s.mu.Lock()
+		taken := s.nicknames[req.Nickname]
+		if !taken {
+			s.nicknames[req.Nickname] = true
+			s.connByNickname[req.Nickname] = conn
+		}
+		s.mu.Unlock()
 
-	decryptedMsg := Decrypt(content)
-	return decryptedMsg, nil
-}
+		if taken {
+			if err := WriteFrame(conn, key, MsgNickname, NicknamePayload{Nickname: req.Nickname, Retry: true}); err != nil {
+				return "", err
+			}
+			continue
+		}
 
-// Player represents a connected client.
-type Player struct {
-	Conn     net.Conn
-	Nickname string
-	Choice   string
-	Score    int
+		if err := WriteFrame(conn, key, MsgNickname, NicknamePayload{Nickname: req.Nickname, Accepted: true}); err != nil {
+			s.releaseNickname(req.Nickname)
+			return "", err
+		}
+		if err := WriteFrame(conn, key, MsgRuleSet, RuleSetPayload{Name: s.RuleSet.Name, Moves: s.RuleSet.Moves, Beats: s.RuleSet.Beats}); err != nil {
+			s.releaseNickname(req.Nickname)
+			return "", err
+		}
+		return req.Nickname, nil
+	}
 }
 
-// Game represents a game between two players.
-type Game struct {
-	Player1 *Player
-	Player2 *Player
-	Rounds  int
+// releaseNickname frees a nickname so it can be reused once its
+// owning connection disconnects.
+func (s *Server) releaseNickname(nickname string) {
+	s.mu.Lock()
+	delete(s.nicknames, nickname)
+	delete(s.connByNickname, nickname)
+	s.mu.Unlock()
 }
 
-// NewServer initializes a new RPS server.
-func NewServer(rounds int, port string) *Server {
-	return &Server{
-		Rounds:         rounds,
-		Port:           port,
-		WaitingPlayers: make(chan net.Conn, 100), // buffer for 100 waiting players
-		Quit:           make(chan os.Signal, 1),
+// kickConn closes the connection registered for nickname, if any, so
+// a pending or later read of its choice fails the same way any other
+// disconnect would. A player still in WaitingPlayers stays queued
+// until the matchmaker pairs it. It reports whether a connection was
+// found.
+func (s *Server) kickConn(nickname string) bool {
+	s.mu.Lock()
+	conn, ok := s.connByNickname[nickname]
+	s.mu.Unlock()
+	if !ok {
+		return false
 	}
+	conn.Close()
+	return true
 }
 
-// Server represents the RPS server.
-type Server struct {
-	Rounds         int
-	Port           string
-	WaitingPlayers chan net.Conn
-	Quit           chan os.Signal
-	wg             sync.WaitGroup
+// matchmaker pairs waiting players two at a time and starts a game for
+// each pair. If MatchmakingTimeout is set and a second player hasn't
+// shown up in time, a RandomBot fills the empty slot instead.
+func (s *Server) matchmaker() {
+	for {
+		p1, ok := <-s.WaitingPlayers
+		if !ok {
+			return
+		}
+
+		p2, ok := s.findOpponent()
+		if !ok {
+			return
+		}
+
+		s.wg.Add(1)
+		go s.runGame(p1, p2)
+	}
 }
 
-// determineOutcome calculates the outcome for each player based on their choices.
-// Returns the score increments for Player1 and Player2 respectively.
-func determineOutcome(p1Choice, p2Choice string) (int, int) {
-	// Rules: rock beats scissors, scissors beats paper, paper beats rock
-	if p1Choice == p2Choice {
-		return 0, 0 // draw
+// findOpponent waits for a second waiting player, falling back to a
+// RandomBot once MatchmakingTimeout elapses. ok is false only when
+// WaitingPlayers has been closed.
+func (s *Server) findOpponent() (Player, bool) {
+	if s.MatchmakingTimeout <= 0 {
+		p2, ok := <-s.WaitingPlayers
+		return p2, ok
+	}
+
+	select {
+	case p2, ok := <-s.WaitingPlayers:
+		return p2, ok
+	case <-time.After(s.MatchmakingTimeout):
+		bot := NewRandomBot(fmt.Sprintf("bot-%d", time.Now().UnixNano()), s.RuleSet)
+		log.Printf("matchmaking timed out, filling empty slot with %s", bot.Nickname())
+		return bot, true
 	}
+}
+
+// runGame plays out a full game between two players, exchanging
+// choices each round and reporting results until all rounds are
+// complete or, with ForfeitMatchOnTimeout, a turn deadline ends the
+// match early. Either way the match is reported and persisted the
+// same way once it ends.
+func (s *Server) runGame(p1, p2 Player) {
+	defer s.wg.Done()
+	defer s.releaseNickname(p1.Nickname())
+	defer s.releaseNickname(p2.Nickname())
+	defer closeConnPlayer(p1)
+	defer closeConnPlayer(p2)
+
+	stopKeepalive(p1)
+	stopKeepalive(p2)
+
+	gameID := newGameID()
+	s.registerGame(gameID, p1.Nickname(), p2.Nickname())
+	defer s.unregisterGame(gameID)
+
+	startedAt := time.Now()
+	score1, score2 := 0, 0
+	var rounds []scorekeeper.RoundChoice
 
-	var outcome1, outcome2 int
+	for round := 0; round < s.Rounds; round++ {
+		roundsLeft := s.Rounds - round - 1
 
-	switch p1Choice {
-	case "rock":
-		if p2Choice == "scissors" {
-			outcome1 = 1 // Player1 wins
-			outcome2 = -1
-		} else { // paper
-			outcome1 = -1 // Player1 loses
-			outcome2 = 1
+		c1, timedOut1, err := s.choose(p1, p2, RoundState{Round: round, RoundsRemaining: roundsLeft + 1, OpponentNickname: p2.Nickname()})
+		if err != nil {
+			log.Printf("round aborted, failed to read choice from %s: %v", p1.Nickname(), err)
+			return
 		}
-	case "paper":
-		if p2Choice == "rock" {
-			outcome1 = 1
-			outcome2 = -1
-		} else { // scissors
-			outcome1 = -1
-			outcome2 = 1
+		c2, timedOut2, err := s.choose(p2, p1, RoundState{Round: round, RoundsRemaining: roundsLeft + 1, OpponentNickname: p1.Nickname()})
+		if err != nil {
+			log.Printf("round aborted, failed to read choice from %s: %v", p2.Nickname(), err)
+			return
 		}
-	case "scissors":
-		if p2Choice == "paper" {
-			outcome1 = 1
-			outcome2 = -1
-		} else { // rock
-			outcome1 = -1
-			outcome2 = 1
+
+		if timedOut1 || timedOut2 {
+			switch {
+			case timedOut1 && !timedOut2:
+				score2++
+			case timedOut2 && !timedOut1:
+				score1++
+			}
+			rounds = append(rounds, scorekeeper.RoundChoice{Round: round, Player1Choice: c1, Player2Choice: c2})
+			s.updateGame(gameID, round, score1, score2)
+			s.publishEvent(GameEvent{GameID: gameID, Round: round, Player1: p1.Nickname(), Player2: p2.Nickname(), Player1Choice: c1, Player2Choice: c2, Score1: score1, Score2: score2})
+
+			if s.ForfeitMatchOnTimeout {
+				log.Printf("match forfeited: %s or %s missed the turn deadline", p1.Nickname(), p2.Nickname())
+				break
+			}
+			continue
+		}
+
+		o1, o2 := s.RuleSet.Outcome(c1, c2)
+		score1 += o1
+		score2 += o2
+		rounds = append(rounds, scorekeeper.RoundChoice{Round: round, Player1Choice: c1, Player2Choice: c2})
+		s.updateGame(gameID, round, score1, score2)
+		s.publishEvent(GameEvent{GameID: gameID, Round: round, Player1: p1.Nickname(), Player2: p2.Nickname(), Player1Choice: c1, Player2Choice: c2, Score1: score1, Score2: score2})
+
+		p1.Notify(RoundOutcome{Round: round, OwnChoice: c1, OpponentChoice: c2, OpponentNickname: p2.Nickname(), Outcome: outcomeString(o1), RoundsLeft: roundsLeft})
+		p2.Notify(RoundOutcome{Round: round, OwnChoice: c2, OpponentChoice: c1, OpponentNickname: p1.Nickname(), Outcome: outcomeString(o2), RoundsLeft: roundsLeft})
+	}
+
+	reportFinalScore(p1, score1, score2)
+	reportFinalScore(p2, score2, score1)
+	s.publishEvent(GameEvent{GameID: gameID, Player1: p1.Nickname(), Player2: p2.Nickname(), Score1: score1, Score2: score2, Final: true})
+
+	if s.ScoreKeeper != nil {
+		winner := ""
+		switch {
+		case score1 > score2:
+			winner = p1.Nickname()
+		case score2 > score1:
+			winner = p2.Nickname()
 		}
+
+		s.ScoreKeeper.Publish(scorekeeper.GameRecord{
+			ID:           gameID,
+			Player1:      p1.Nickname(),
+			Player2:      p2.Nickname(),
+			Player1Score: score1,
+			Player2Score: score2,
+			Winner:       winner,
+			Rounds:       rounds,
+			StartedAt:    startedAt,
+			EndedAt:      time.Now(),
+		})
+	}
+}
+
+// newGameID returns a random identifier used to key a persisted
+// GameRecord.
+func newGameID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("game-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// registerGame records a newly started game so the RESP admin
+// server's GAMES command can list it.
+func (s *Server) registerGame(id, player1, player2 string) {
+	s.gamesMu.Lock()
+	s.games[id] = &gameInfo{ID: id, Player1: player1, Player2: player2, TotalRounds: s.Rounds}
+	s.gamesMu.Unlock()
+}
+
+// unregisterGame removes a finished or aborted game from the registry.
+func (s *Server) unregisterGame(id string) {
+	s.gamesMu.Lock()
+	delete(s.games, id)
+	s.gamesMu.Unlock()
+}
+
+// updateGame records the latest round and score for an in-progress
+// game.
+func (s *Server) updateGame(id string, round, score1, score2 int) {
+	s.gamesMu.Lock()
+	if gi, ok := s.games[id]; ok {
+		gi.Round = round
+		gi.Score1 = score1
+		gi.Score2 = score2
+	}
+	s.gamesMu.Unlock()
+}
+
+// publishEvent JSON-encodes evt and publishes it to the game's
+// "games/<id>" channel, if event broadcasting is enabled. A broken
+// spectator feed should never affect gameplay, so encoding failures
+// are only logged.
+func (s *Server) publishEvent(evt GameEvent) {
+	if s.Events == nil {
+		return
+	}
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		log.Printf("failed to encode game event: %v", err)
+		return
+	}
+	s.Events.Publish("games/"+evt.GameID, payload)
+}
+
+// reportFinalScore notifies p of the final score if it implements
+// ScoreReporter.
+func reportFinalScore(p Player, score, opponentScore int) {
+	if reporter, ok := p.(ScoreReporter); ok {
+		reporter.ReportFinalScore(score, opponentScore)
 	}
+}
+
+// closeConnPlayer closes the underlying connection if p is a
+// ConnPlayer; bots have no connection to close.
+func closeConnPlayer(p Player) {
+	if cp, ok := p.(*ConnPlayer); ok {
+		cp.Conn.Close()
+	}
+}
 
-	return outcome1, outcome2
+// stopKeepalive stops a ConnPlayer's waiting-room pinger now that it
+// has been dequeued into a game; bots have no pinger to stop.
+func stopKeepalive(p Player) {
+	if cp, ok := p.(*ConnPlayer); ok {
+		cp.stopWaitingRoomPing()
+	}
+}
+
+// choose asks p to choose within s.TurnTimeout. If p misses the
+// deadline, opponent is sent a TIMEOUT message (when it implements
+// TimeoutNotifier) and choose returns timedOut=true with a zero error;
+// any other failure to read a choice is returned as err.
+func (s *Server) choose(p, opponent Player, state RoundState) (choice string, timedOut bool, err error) {
+	ctx, cancel := s.turnContext()
+	defer cancel()
+
+	choice, err = p.Choose(ctx, state)
+	if err == nil {
+		return choice, false, nil
+	}
+	if !isTimeout(err) {
+		return "", false, err
+	}
+
+	notifyTimeout(opponent, p.Nickname(), s.ForfeitMatchOnTimeout)
+	return "", true, nil
+}
+
+// turnContext returns a context bounded by s.TurnTimeout, or an
+// unbounded context if TurnTimeout is not set.
+func (s *Server) turnContext() (context.Context, context.CancelFunc) {
+	if s.TurnTimeout <= 0 {
+		return context.Background(), func() {}
+	}
+	return context.WithTimeout(context.Background(), s.TurnTimeout)
+}
+
+// isTimeout reports whether err was caused by a read deadline set via
+// conn.SetReadDeadline expiring.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// notifyTimeout tells opponent that p failed to choose before the
+// turn deadline, if opponent implements TimeoutNotifier; bots have no
+// one to notify.
+func notifyTimeout(opponent Player, timedOutNickname string, matchForfeited bool) {
+	if tn, ok := opponent.(TimeoutNotifier); ok {
+		tn.NotifyTimeout(timedOutNickname, matchForfeited)
+	}
 }
 
 // outcomeString converts the outcome integer to a string representation.
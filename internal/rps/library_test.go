@@ -2,15 +2,234 @@
 package rps
 
 import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net"
 	"testing"
+	"time"
+
+	"rps_game/internal/rps/scorekeeper"
 )
 
-func TestEncryptDecrypt(t *testing.T) {
-	original := "rock"
-	encrypted := Encrypt(original)
-	decrypted := Decrypt(encrypted)
+func TestWriteReadFrameRoundTrip(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	key := []byte("test-session-key")
+	want := ChoicePayload{Choice: "rock"}
+
+	go func() {
+		if err := WriteFrame(server, key, MsgChoice, want); err != nil {
+			t.Errorf("WriteFrame() error = %v", err)
+		}
+	}()
+
+	msgType, payload, err := ReadFrame(client, key)
+	if err != nil {
+		t.Fatalf("ReadFrame() error = %v", err)
+	}
+	if msgType != MsgChoice {
+		t.Errorf("ReadFrame() type = %s; want %s", msgType, MsgChoice)
+	}
+
+	var got ChoicePayload
+	if err := json.Unmarshal(payload, &got); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if got != want {
+		t.Errorf("decoded payload = %+v; want %+v", got, want)
+	}
+}
+
+func TestReadFrameRejectsTamperedTag(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	go WriteFrame(server, []byte("key-a"), MsgPing, struct{}{})
+
+	if _, _, err := ReadFrame(client, []byte("key-b")); err == nil {
+		t.Error("ReadFrame() with wrong key = nil error; want authentication failure")
+	}
+}
+
+func TestReadFrameRejectsOversizedLength(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	go func() {
+		var lenBuf [4]byte
+		binary.BigEndian.PutUint32(lenBuf[:], maxFrameSize+1)
+		server.Write(lenBuf[:])
+	}()
+
+	if _, _, err := ReadFrame(client, []byte("key")); err == nil {
+		t.Error("ReadFrame() with an oversized length = nil error; want it rejected before allocating")
+	}
+}
+
+// timeoutError is a net.Error that always reports itself as a
+// timeout, standing in for the error ReadFrame returns once
+// SetReadDeadline expires.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// fakePlayer is a minimal Player for exercising Server.choose without
+// a real connection.
+type fakePlayer struct {
+	nickname string
+	choice   string
+	err      error
+}
+
+func (p *fakePlayer) Nickname() string { return p.nickname }
+func (p *fakePlayer) Choose(ctx context.Context, state RoundState) (string, error) {
+	return p.choice, p.err
+}
+func (p *fakePlayer) Notify(outcome RoundOutcome) {}
+
+// timeoutNotifyingPlayer embeds fakePlayer and records NotifyTimeout
+// calls so tests can assert the opponent was told about a timeout.
+type timeoutNotifyingPlayer struct {
+	fakePlayer
+	notifiedNickname string
+	matchForfeited   bool
+	notified         bool
+}
+
+func (p *timeoutNotifyingPlayer) NotifyTimeout(opponentNickname string, matchForfeited bool) {
+	p.notified = true
+	p.notifiedNickname = opponentNickname
+	p.matchForfeited = matchForfeited
+}
+
+func TestServerChooseReturnsChoice(t *testing.T) {
+	s := &Server{}
+	p := &fakePlayer{nickname: "alice", choice: "rock"}
+	opponent := &fakePlayer{nickname: "bob"}
+
+	choice, timedOut, err := s.choose(p, opponent, RoundState{})
+	if err != nil {
+		t.Fatalf("choose() error = %v", err)
+	}
+	if timedOut {
+		t.Error("choose() timedOut = true; want false")
+	}
+	if choice != "rock" {
+		t.Errorf("choose() choice = %q; want %q", choice, "rock")
+	}
+}
+
+func TestServerChooseNotifiesOpponentOnTimeout(t *testing.T) {
+	s := &Server{TurnTimeout: time.Second}
+	p := &fakePlayer{nickname: "alice", err: timeoutError{}}
+	opponent := &timeoutNotifyingPlayer{fakePlayer: fakePlayer{nickname: "bob"}}
+
+	_, timedOut, err := s.choose(p, opponent, RoundState{})
+	if err != nil {
+		t.Fatalf("choose() error = %v; want nil", err)
+	}
+	if !timedOut {
+		t.Fatal("choose() timedOut = false; want true")
+	}
+	if !opponent.notified {
+		t.Fatal("NotifyTimeout() was not called on opponent")
+	}
+	if opponent.notifiedNickname != "alice" || opponent.matchForfeited {
+		t.Errorf("NotifyTimeout(%q, %v); want (%q, false)", opponent.notifiedNickname, opponent.matchForfeited, "alice")
+	}
+}
+
+func TestServerChoosePropagatesNonTimeoutError(t *testing.T) {
+	s := &Server{}
+	wantErr := errors.New("connection reset")
+	p := &fakePlayer{nickname: "alice", err: wantErr}
+	opponent := &fakePlayer{nickname: "bob"}
+
+	_, timedOut, err := s.choose(p, opponent, RoundState{})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("choose() error = %v; want %v", err, wantErr)
+	}
+	if timedOut {
+		t.Error("choose() timedOut = true; want false")
+	}
+}
+
+func TestRunGamePersistsAndPublishesForfeitedMatch(t *testing.T) {
+	store := scorekeeper.NewMemoryStore()
+	sk := scorekeeper.New(store)
+
+	s := &Server{
+		Rounds:                3,
+		RuleSet:               ClassicRuleSet,
+		ForfeitMatchOnTimeout: true,
+		ScoreKeeper:           sk,
+		games:                 make(map[string]*gameInfo),
+	}
+	s.wg.Add(1)
+
+	p1 := &fakePlayer{nickname: "alice", choice: "rock"}
+	p2 := &fakePlayer{nickname: "bob", err: timeoutError{}}
+
+	s.runGame(p1, p2)
+
+	if err := sk.Close(); err != nil {
+		t.Fatalf("scorekeeper.Close() error = %v", err)
+	}
+
+	board, err := store.Leaderboard(context.Background())
+	if err != nil {
+		t.Fatalf("Leaderboard() error = %v", err)
+	}
+	if len(board) == 0 {
+		t.Fatal("Leaderboard() is empty; want the forfeited match to have been recorded")
+	}
+}
+
+func TestEventBroadcasterDeliversToExactSubscriber(t *testing.T) {
+	b := NewEventBroadcaster()
+	msgs, cancel := b.Subscribe("games/1")
+	defer cancel()
+
+	b.Publish("games/1", []byte("round 1"))
+	b.Publish("games/2", []byte("ignored"))
+
+	select {
+	case msg := <-msgs:
+		if msg.Channel != "games/1" || string(msg.Payload) != "round 1" {
+			t.Errorf("got %+v; want channel games/1 payload %q", msg, "round 1")
+		}
+	default:
+		t.Fatal("expected a message on the subscribed channel")
+	}
+
+	select {
+	case msg := <-msgs:
+		t.Errorf("unexpected second message %+v", msg)
+	default:
+	}
+}
+
+func TestEventBroadcasterDeliversToMatchingPattern(t *testing.T) {
+	b := NewEventBroadcaster()
+	msgs, cancel := b.SubscribePattern("games/*")
+	defer cancel()
+
+	b.Publish("games/42", []byte("final"))
 
-	if original != decrypted {
-		t.Errorf("Decrypt(Encrypt(%s)) = %s; want %s", original, decrypted, original)
+	select {
+	case msg := <-msgs:
+		if msg.Channel != "games/42" {
+			t.Errorf("Channel = %q; want %q", msg.Channel, "games/42")
+		}
+	default:
+		t.Fatal("expected a message on the matching pattern")
 	}
 }
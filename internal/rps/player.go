@@ -0,0 +1,160 @@
+// internal/rps/player.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Defines the Player abstraction so the game loop can drive
+//          network-backed humans and automated bots identically.
+// **************************************************************
+
+package rps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+)
+
+// RoundState describes what a Player needs to know to choose a move
+// for the current round.
+type RoundState struct {
+	Round            int
+	RoundsRemaining  int
+	OpponentNickname string
+}
+
+// RoundOutcome reports the result of a finished round to a Player.
+type RoundOutcome struct {
+	Round            int
+	OwnChoice        string
+	OpponentChoice   string
+	OpponentNickname string
+	Outcome          string
+	RoundsLeft       int
+}
+
+// Player is anything that can take part in a game: pick a move each
+// round, be told how it turned out, and report a nickname. The
+// server's game loop depends only on this interface, not on whether a
+// choice comes from a human over the network or an automated bot.
+type Player interface {
+	Nickname() string
+	Choose(ctx context.Context, state RoundState) (string, error)
+	Notify(outcome RoundOutcome)
+}
+
+// ScoreReporter is an optional extension a Player implements to
+// receive the final score once a game ends. Bots that only care about
+// per-round results can leave it unimplemented.
+type ScoreReporter interface {
+	ReportFinalScore(score, opponentScore int)
+}
+
+// TimeoutNotifier is an optional extension a Player implements to be
+// told that its opponent failed to choose before the per-turn
+// deadline. Bots never time out an opponent's round, so they have no
+// need to implement it.
+type TimeoutNotifier interface {
+	NotifyTimeout(opponentNickname string, matchForfeited bool)
+}
+
+// ConnPlayer is a Player backed by a framed, authenticated net.Conn,
+// i.e. a human playing over the network. It is the only Player
+// implementation the server's matchmaker ever reads from a
+// net.Listener; bots are constructed directly.
+type ConnPlayer struct {
+	Conn     net.Conn
+	Key      []byte
+	nickname string
+
+	stopPing     chan struct{}
+	stopPingOnce sync.Once
+}
+
+// NewConnPlayer wraps an accepted, nickname-negotiated connection as a
+// Player.
+func NewConnPlayer(conn net.Conn, key []byte, nickname string) *ConnPlayer {
+	return &ConnPlayer{Conn: conn, Key: key, nickname: nickname, stopPing: make(chan struct{})}
+}
+
+// stopWaitingRoomPing signals the server's keepalive pinger to stop,
+// now that the player has either been dequeued into a game or the
+// pinger itself has closed a dead connection. Safe to call more than
+// once.
+func (p *ConnPlayer) stopWaitingRoomPing() {
+	p.stopPingOnce.Do(func() { close(p.stopPing) })
+}
+
+// Nickname returns the player's nickname.
+func (p *ConnPlayer) Nickname() string {
+	return p.nickname
+}
+
+// Choose blocks until the connection sends a CHOICE frame for
+// state.Round, respecting ctx's deadline if it has one. A CHOICE frame
+// for an earlier round is discarded rather than returned: it can only
+// be one that the client sent just after missing a previous turn
+// deadline, too late to count, and returning it here would silently
+// shift it onto this round instead.
+func (p *ConnPlayer) Choose(ctx context.Context, state RoundState) (string, error) {
+	if dl, ok := ctx.Deadline(); ok {
+		p.Conn.SetReadDeadline(dl)
+	} else {
+		p.Conn.SetReadDeadline(time.Time{})
+	}
+
+	for {
+		msgType, payload, err := ReadFrame(p.Conn, p.Key)
+		if err != nil {
+			return "", err
+		}
+		if msgType != MsgChoice {
+			return "", fmt.Errorf("expected CHOICE frame, got %s", msgType)
+		}
+
+		var choice ChoicePayload
+		if err := json.Unmarshal(payload, &choice); err != nil {
+			return "", fmt.Errorf("failed to decode choice payload: %w", err)
+		}
+		if choice.Round != state.Round {
+			log.Printf("%s: discarding stale CHOICE for round %d while awaiting round %d", p.nickname, choice.Round, state.Round)
+			continue
+		}
+		return choice.Choice, nil
+	}
+}
+
+// Notify sends the round result to the connection as a ROUND_RESULT
+// frame.
+func (p *ConnPlayer) Notify(outcome RoundOutcome) {
+	if err := WriteFrame(p.Conn, p.Key, MsgRoundResult, RoundResultPayload{
+		OpponentNickname: outcome.OpponentNickname,
+		OpponentChoice:   outcome.OpponentChoice,
+		Outcome:          outcome.Outcome,
+		RoundsLeft:       outcome.RoundsLeft,
+	}); err != nil {
+		log.Printf("failed to send round result to %s: %v", p.nickname, err)
+	}
+}
+
+// ReportFinalScore sends the final score to the connection as a
+// FINAL_SCORE frame.
+func (p *ConnPlayer) ReportFinalScore(score, opponentScore int) {
+	if err := WriteFrame(p.Conn, p.Key, MsgFinalScore, FinalScorePayload{Score: score, OpponentScore: opponentScore}); err != nil {
+		log.Printf("failed to send final score to %s: %v", p.nickname, err)
+	}
+}
+
+// NotifyTimeout sends a TIMEOUT frame telling the connection that
+// opponentNickname failed to choose before the per-turn deadline, and
+// whether the whole match was forfeited as a result.
+func (p *ConnPlayer) NotifyTimeout(opponentNickname string, matchForfeited bool) {
+	if err := WriteFrame(p.Conn, p.Key, MsgTimeout, TimeoutPayload{
+		OpponentNickname: opponentNickname,
+		MatchForfeited:   matchForfeited,
+	}); err != nil {
+		log.Printf("failed to send timeout notice to %s: %v", p.nickname, err)
+	}
+}
@@ -0,0 +1,32 @@
+// internal/rps/player_test.go
+package rps
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestConnPlayerChooseDiscardsStaleRoundFrame(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	key := []byte("test-session-key")
+	p := NewConnPlayer(server, key, "alice")
+
+	go func() {
+		// A CHOICE for round 0 that the client sent just after missing
+		// its turn deadline, followed by the real answer for round 1.
+		WriteFrame(client, key, MsgChoice, ChoicePayload{Choice: "rock", Round: 0})
+		WriteFrame(client, key, MsgChoice, ChoicePayload{Choice: "scissors", Round: 1})
+	}()
+
+	choice, err := p.Choose(context.Background(), RoundState{Round: 1})
+	if err != nil {
+		t.Fatalf("Choose() error = %v", err)
+	}
+	if choice != "scissors" {
+		t.Errorf("Choose() = %q; want %q (the stale round-0 frame should have been discarded)", choice, "scissors")
+	}
+}
@@ -0,0 +1,231 @@
+// internal/rps/protocol.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Length-prefixed wire protocol shared by the client and
+//          server, with an HMAC tag on every frame guarding against
+//          corruption and tampering in transit (integrity only, not
+//          confidentiality - see helloKey). Replaces the old
+//          single-byte header/footer + Caesar-shift framing in
+//          library.go.
+// **************************************************************
+
+package rps
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+// MessageType identifies the kind of payload carried by a frame.
+type MessageType byte
+
+const (
+	MsgHello MessageType = iota + 1
+	MsgNickname
+	MsgChoice
+	MsgRoundResult
+	MsgFinalScore
+	MsgPing
+	MsgPong
+	MsgDisconnect
+	MsgTimeout
+	MsgRuleSet
+)
+
+// String returns the wire name of the message type, as used in log
+// messages and error text.
+func (t MessageType) String() string {
+	switch t {
+	case MsgHello:
+		return "HELLO"
+	case MsgNickname:
+		return "NICKNAME"
+	case MsgChoice:
+		return "CHOICE"
+	case MsgRoundResult:
+		return "ROUND_RESULT"
+	case MsgFinalScore:
+		return "FINAL_SCORE"
+	case MsgPing:
+		return "PING"
+	case MsgPong:
+		return "PONG"
+	case MsgDisconnect:
+		return "DISCONNECT"
+	case MsgTimeout:
+		return "TIMEOUT"
+	case MsgRuleSet:
+		return "RULE_SET"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", byte(t))
+	}
+}
+
+// tagSize is the length in bytes of the HMAC-SHA256 authentication tag
+// appended to every frame.
+const tagSize = sha256.Size
+
+// maxFrameSize bounds the length a frame header is allowed to declare,
+// so a connection cannot make ReadFrame allocate an arbitrarily large
+// buffer before any handshake or authentication has even happened.
+// Every real payload (including the largest built-in RuleSet, RPS101)
+// fits comfortably within this.
+const maxFrameSize = 1 << 20 // 1 MiB
+
+// helloKey authenticates only the very first HELLO frame, before a
+// per-connection session key has been negotiated. It is a fixed,
+// public, all-zero value, not a secret.
+//
+// Frame "authentication" in this protocol is integrity-only: the HMAC
+// tag detects corruption or tampering in transit, but the session key
+// itself is handed out in plaintext inside the HELLO payload, so a
+// network observer who can see that frame can read the key and forge
+// or decrypt everything that follows. This protocol provides no
+// confidentiality and no real defense against a network attacker; it
+// is not a substitute for running the connection over TLS.
+var helloKey = make([]byte, tagSize)
+
+// HelloKey returns the fixed key used to write and read the HELLO
+// frame that bootstraps a connection's session key.
+func HelloKey() []byte {
+	return helloKey
+}
+
+// HelloPayload carries the per-connection session key the server
+// generates for a newly accepted player, in plaintext. Every frame
+// after the HELLO exchange is authenticated (not encrypted) with this
+// key; see helloKey for why that authentication doesn't defend against
+// an attacker who can observe the connection.
+type HelloPayload struct {
+	Key []byte `json:"key"`
+}
+
+// NicknamePayload is sent by the client to request a nickname and by
+// the server to accept or reject it.
+type NicknamePayload struct {
+	Nickname string `json:"nickname"`
+	Accepted bool   `json:"accepted,omitempty"`
+	Retry    bool   `json:"retry,omitempty"`
+}
+
+// ChoicePayload carries a player's move for the current round, one of
+// the active RuleSet's Moves. Round identifies which round the choice
+// is for, so a CHOICE frame that arrives just after its turn deadline
+// expired can be recognized as stale and discarded instead of being
+// folded into the next round (see ConnPlayer.Choose).
+type ChoicePayload struct {
+	Choice string `json:"choice"`
+	Round  int    `json:"round"`
+}
+
+// RoundResultPayload reports the outcome of a finished round to one
+// player.
+type RoundResultPayload struct {
+	OpponentNickname string `json:"opponent_nickname"`
+	OpponentChoice   string `json:"opponent_choice"`
+	Outcome          string `json:"outcome"`
+	RoundsLeft       int    `json:"rounds_left"`
+}
+
+// FinalScorePayload reports the final score once a game ends, either
+// after all rounds or when a turn timeout forfeits the match.
+type FinalScorePayload struct {
+	Score         int `json:"score"`
+	OpponentScore int `json:"opponent_score"`
+}
+
+// DisconnectPayload explains why a connection is being closed.
+type DisconnectPayload struct {
+	Reason string `json:"reason"`
+}
+
+// TimeoutPayload tells a player that their opponent failed to choose
+// in time, and whether the whole match was forfeited as a result.
+type TimeoutPayload struct {
+	OpponentNickname string `json:"opponent_nickname"`
+	MatchForfeited   bool   `json:"match_forfeited"`
+}
+
+// RuleSetPayload describes the server's active RuleSet, sent once to
+// each player right after their nickname is accepted so the client can
+// drive its choice prompt off the real move list instead of assuming
+// classic rock/paper/scissors.
+type RuleSetPayload struct {
+	Name  string                       `json:"name"`
+	Moves []string                     `json:"moves"`
+	Beats map[string]map[string]string `json:"beats"`
+}
+
+// WriteFrame JSON-encodes payload and writes it to conn as a
+// length-prefixed, authenticated frame:
+//
+//	4 bytes  big-endian length of (type + payload + tag)
+//	1 byte   MessageType
+//	N bytes  JSON-encoded payload
+//	32 bytes HMAC-SHA256(key, type||payload)
+//
+// key is the per-connection session key negotiated during the HELLO
+// exchange; pass HelloKey() when writing the HELLO frame itself.
+func WriteFrame(conn net.Conn, key []byte, msgType MessageType, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s payload: %w", msgType, err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte{byte(msgType)})
+	mac.Write(body)
+	tag := mac.Sum(nil)
+
+	frame := make([]byte, 0, 4+1+len(body)+tagSize)
+	frame = binary.BigEndian.AppendUint32(frame, uint32(1+len(body)+tagSize))
+	frame = append(frame, byte(msgType))
+	frame = append(frame, body...)
+	frame = append(frame, tag...)
+
+	if _, err := conn.Write(frame); err != nil {
+		return fmt.Errorf("failed to write %s frame: %w", msgType, err)
+	}
+	return nil
+}
+
+// ReadFrame reads and authenticates one frame from conn, returning its
+// type and raw JSON payload. Decode the payload with json.Unmarshal
+// into the struct matching msgType.
+func ReadFrame(conn net.Conn, key []byte) (MessageType, []byte, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(conn, lenBuf[:]); err != nil {
+		return 0, nil, fmt.Errorf("failed to read frame length: %w", err)
+	}
+	length := binary.BigEndian.Uint32(lenBuf[:])
+	if length < 1+tagSize {
+		return 0, nil, errors.New("frame too short to contain a type and authentication tag")
+	}
+	if length > maxFrameSize {
+		return 0, nil, fmt.Errorf("frame length %d exceeds maximum of %d bytes", length, maxFrameSize)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(conn, body); err != nil {
+		return 0, nil, fmt.Errorf("failed to read frame body: %w", err)
+	}
+
+	msgType := MessageType(body[0])
+	payload := body[1 : len(body)-tagSize]
+	tag := body[len(body)-tagSize:]
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body[:1])
+	mac.Write(payload)
+	if !hmac.Equal(tag, mac.Sum(nil)) {
+		return 0, nil, fmt.Errorf("invalid authentication tag on %s frame", msgType)
+	}
+
+	return msgType, payload, nil
+}
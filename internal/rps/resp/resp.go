@@ -0,0 +1,112 @@
+// internal/rps/resp/resp.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Minimal RESP (REdis Serialization Protocol) codec - just
+//          enough of the wire format for a stock redis-cli to issue
+//          commands against and receive replies from, without pulling
+//          in a full Redis client or server implementation.
+// **************************************************************
+
+package resp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Command is one client request, parsed from a RESP array of bulk
+// strings. Name is upper-cased for case-insensitive dispatch; Args
+// keeps the client's original casing.
+type Command struct {
+	Name string
+	Args []string
+}
+
+// maxElements bounds both an array command's declared argument count
+// and a bulk string's declared length, so a malicious or malformed
+// header (e.g. a huge count, or a negative one that would underflow)
+// can't force a runaway or invalid allocation.
+const maxElements = 1 << 20 // 1 MiB
+
+// ReadCommand reads one request from r. Clients are expected to send
+// commands as RESP arrays of bulk strings, the format every real
+// Redis client (including redis-cli) uses; a bare newline-terminated
+// line of space-separated words is also accepted as an "inline
+// command", for use with plain tools like netcat.
+func ReadCommand(r *bufio.Reader) (Command, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return Command{}, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return Command{}, nil
+	}
+
+	if !strings.HasPrefix(line, "*") {
+		return inlineCommand(line), nil
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return Command{}, fmt.Errorf("resp: malformed array header %q: %w", line, err)
+	}
+	if n <= 0 {
+		return Command{}, fmt.Errorf("resp: empty command")
+	}
+	if n > maxElements {
+		return Command{}, fmt.Errorf("resp: array header %q exceeds maximum of %d elements", line, maxElements)
+	}
+
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		arg, err := readBulkString(r)
+		if err != nil {
+			return Command{}, err
+		}
+		args = append(args, arg)
+	}
+	return Command{Name: strings.ToUpper(args[0]), Args: args[1:]}, nil
+}
+
+// inlineCommand parses a plain space-separated line as a command, the
+// way Redis falls back for interactive tools that don't speak RESP.
+func inlineCommand(line string) Command {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return Command{}
+	}
+	return Command{Name: strings.ToUpper(fields[0]), Args: fields[1:]}
+}
+
+// readBulkString reads one "$<len>\r\n<data>\r\n" value.
+func readBulkString(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, "$") {
+		return "", fmt.Errorf("resp: expected bulk string header, got %q", line)
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return "", fmt.Errorf("resp: malformed bulk string header %q: %w", line, err)
+	}
+	if n < 0 {
+		return "", fmt.Errorf("resp: negative bulk string length %q", line)
+	}
+	if n > maxElements {
+		return "", fmt.Errorf("resp: bulk string header %q exceeds maximum of %d bytes", line, maxElements)
+	}
+
+	buf := make([]byte, n+2) // +2 for the trailing CRLF
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
@@ -0,0 +1,82 @@
+// internal/rps/resp/resp_test.go
+package resp
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestReadCommandParsesArray(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*2\r\n$4\r\nKICK\r\n$5\r\nalice\r\n"))
+
+	cmd, err := ReadCommand(r)
+	if err != nil {
+		t.Fatalf("ReadCommand() error = %v", err)
+	}
+	if cmd.Name != "KICK" {
+		t.Errorf("Name = %q; want %q", cmd.Name, "KICK")
+	}
+	if len(cmd.Args) != 1 || cmd.Args[0] != "alice" {
+		t.Errorf("Args = %v; want [alice]", cmd.Args)
+	}
+}
+
+func TestReadCommandRejectsNegativeBulkLength(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*1\r\n$-5\r\n"))
+
+	if _, err := ReadCommand(r); err == nil {
+		t.Error("ReadCommand() error = nil; want an error for a negative bulk string length")
+	}
+}
+
+func TestReadCommandRejectsOversizedArrayCount(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*99999999999\r\n"))
+
+	if _, err := ReadCommand(r); err == nil {
+		t.Error("ReadCommand() error = nil; want an error for an oversized array count")
+	}
+}
+
+func TestReadCommandRejectsOversizedBulkLength(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*1\r\n$99999999999\r\n"))
+
+	if _, err := ReadCommand(r); err == nil {
+		t.Error("ReadCommand() error = nil; want an error for an oversized bulk string length")
+	}
+}
+
+func TestReadCommandParsesInlineCommand(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("games\r\n"))
+
+	cmd, err := ReadCommand(r)
+	if err != nil {
+		t.Fatalf("ReadCommand() error = %v", err)
+	}
+	if cmd.Name != "GAMES" {
+		t.Errorf("Name = %q; want %q", cmd.Name, "GAMES")
+	}
+	if len(cmd.Args) != 0 {
+		t.Errorf("Args = %v; want none", cmd.Args)
+	}
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	var sb strings.Builder
+	w := NewWriter(&sb)
+
+	if err := w.WriteSimpleString("OK"); err != nil {
+		t.Fatalf("WriteSimpleString() error = %v", err)
+	}
+	if err := w.WriteError("ERR boom"); err != nil {
+		t.Fatalf("WriteError() error = %v", err)
+	}
+	if err := w.WriteStringArray([]string{"alice", "bob"}); err != nil {
+		t.Fatalf("WriteStringArray() error = %v", err)
+	}
+
+	want := "+OK\r\n" + "-ERR boom\r\n" + "*2\r\n$5\r\nalice\r\n$3\r\nbob\r\n"
+	if sb.String() != want {
+		t.Errorf("written = %q; want %q", sb.String(), want)
+	}
+}
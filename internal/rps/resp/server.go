@@ -0,0 +1,94 @@
+// internal/rps/resp/server.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: A minimal RESP command server, similar in spirit to
+//          redcon: accept connections, parse commands, and dispatch
+//          them to registered handlers by name. Carries no knowledge
+//          of Rock, Paper, Scissors itself.
+// **************************************************************
+
+package resp
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+)
+
+// HandlerFunc handles one parsed Command, writing its reply through
+// w. A handler for a pub/sub-style command like SUBSCRIBE may block
+// for the life of the connection, pushing further messages through w
+// as they occur.
+type HandlerFunc func(w *Writer, cmd Command)
+
+// Server is a minimal RESP command server: it accepts connections,
+// parses commands, and dispatches them to registered handlers, closely
+// enough to the real protocol that any redis-cli can drive it.
+type Server struct {
+	handlers map[string]HandlerFunc
+}
+
+// NewServer returns a Server with no handlers registered; a command
+// with no matching handler gets an "ERR unknown command" reply.
+func NewServer() *Server {
+	return &Server{handlers: make(map[string]HandlerFunc)}
+}
+
+// Handle registers fn to handle commands named name (case-insensitive).
+func (s *Server) Handle(name string, fn HandlerFunc) {
+	s.handlers[strings.ToUpper(name)] = fn
+}
+
+// ListenAndServe listens on addr and serves RESP connections until the
+// listener errors, e.g. because it was closed.
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("resp: failed to listen on %s: %w", addr, err)
+	}
+	defer listener.Close()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// serveConn reads and dispatches commands from conn until it errors or
+// closes. A panic while parsing or handling a command (from a
+// malformed request this package failed to reject cleanly, or from a
+// buggy handler) is recovered here so one bad connection can't take
+// down the rest of the server.
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("resp: recovered from panic serving %s: %v", conn.RemoteAddr(), r)
+		}
+	}()
+
+	r := bufio.NewReader(conn)
+	w := NewWriter(conn)
+
+	for {
+		cmd, err := ReadCommand(r)
+		if err != nil {
+			return
+		}
+		if cmd.Name == "" {
+			continue
+		}
+
+		handler, ok := s.handlers[cmd.Name]
+		if !ok {
+			w.WriteError(fmt.Sprintf("ERR unknown command %q", cmd.Name))
+			continue
+		}
+		handler(w, cmd)
+	}
+}
@@ -0,0 +1,65 @@
+// internal/rps/resp/server_test.go
+package resp
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestServerRecoversFromHandlerPanic(t *testing.T) {
+	s := NewServer()
+	s.Handle("BOOM", func(w *Writer, cmd Command) { panic("handler exploded") })
+	s.Handle("PING", func(w *Writer, cmd Command) { w.WriteSimpleString("PONG") })
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serveConn(conn)
+		}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("BOOM\r\n")); err != nil {
+		t.Fatalf("failed to send BOOM: %v", err)
+	}
+
+	// The panicking connection should be closed, not crash the process.
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := bufio.NewReader(conn).ReadByte(); err == nil {
+		t.Error("ReadByte() after a handler panic = nil error; want the connection closed")
+	}
+
+	conn2, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to dial after panic: %v", err)
+	}
+	defer conn2.Close()
+
+	if _, err := conn2.Write([]byte("PING\r\n")); err != nil {
+		t.Fatalf("failed to send PING: %v", err)
+	}
+	conn2.SetReadDeadline(time.Now().Add(2 * time.Second))
+	reply, err := bufio.NewReader(conn2).ReadString('\n')
+	if err != nil {
+		t.Fatalf("failed to read PING reply after a prior panic: %v", err)
+	}
+	if reply != "+PONG\r\n" {
+		t.Errorf("reply = %q; want %q", reply, "+PONG\r\n")
+	}
+}
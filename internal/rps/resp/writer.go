@@ -0,0 +1,74 @@
+// internal/rps/resp/writer.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Encodes RESP reply values: simple strings, errors,
+//          integers, bulk strings, and arrays.
+// **************************************************************
+
+package resp
+
+import (
+	"fmt"
+	"io"
+)
+
+// Writer encodes RESP reply values onto an underlying connection.
+type Writer struct {
+	w io.Writer
+}
+
+// NewWriter wraps w as a RESP reply encoder.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// WriteSimpleString writes a "+" status reply, e.g. +OK.
+func (w *Writer) WriteSimpleString(s string) error {
+	_, err := fmt.Fprintf(w.w, "+%s\r\n", s)
+	return err
+}
+
+// WriteError writes a "-" error reply.
+func (w *Writer) WriteError(msg string) error {
+	_, err := fmt.Fprintf(w.w, "-%s\r\n", msg)
+	return err
+}
+
+// WriteInteger writes a ":" integer reply.
+func (w *Writer) WriteInteger(n int) error {
+	_, err := fmt.Fprintf(w.w, ":%d\r\n", n)
+	return err
+}
+
+// WriteBulkString writes a "$" bulk string reply.
+func (w *Writer) WriteBulkString(s string) error {
+	_, err := fmt.Fprintf(w.w, "$%d\r\n%s\r\n", len(s), s)
+	return err
+}
+
+// WriteNullBulkString writes the reply used for a missing value, e.g.
+// a lookup that found nothing.
+func (w *Writer) WriteNullBulkString() error {
+	_, err := fmt.Fprint(w.w, "$-1\r\n")
+	return err
+}
+
+// WriteArrayHeader writes a "*" array header for n elements; the
+// caller is responsible for writing exactly n further values.
+func (w *Writer) WriteArrayHeader(n int) error {
+	_, err := fmt.Fprintf(w.w, "*%d\r\n", n)
+	return err
+}
+
+// WriteStringArray writes a complete array of bulk strings.
+func (w *Writer) WriteStringArray(items []string) error {
+	if err := w.WriteArrayHeader(len(items)); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := w.WriteBulkString(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
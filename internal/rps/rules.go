@@ -0,0 +1,189 @@
+// internal/rps/rules.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Data-driven rules engine for generalized Rock-Paper-
+//          Scissors variants. Replaces the old hard-coded
+//          determineOutcome switch with a RuleSet a server can load
+//          from a built-in name or a custom JSON file.
+// **************************************************************
+
+package rps
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// RuleSet describes the moves available in a game and which move beats
+// which. Beats[winner][loser] holds the verb describing the win (e.g.
+// "crushes"), and is also what Outcome consults to score a round.
+type RuleSet struct {
+	Name  string                       `json:"name"`
+	Moves []string                     `json:"moves"`
+	Beats map[string]map[string]string `json:"beats"`
+}
+
+// Validate reports whether rs is complete and consistent: every move
+// is listed exactly once in Moves, and every distinct pair of moves has
+// exactly one winner.
+func (rs RuleSet) Validate() error {
+	if len(rs.Moves) < 3 {
+		return fmt.Errorf("rules: need at least 3 moves, got %d", len(rs.Moves))
+	}
+
+	known := make(map[string]bool, len(rs.Moves))
+	for _, m := range rs.Moves {
+		if known[m] {
+			return fmt.Errorf("rules: duplicate move %q", m)
+		}
+		known[m] = true
+	}
+
+	for winner, losers := range rs.Beats {
+		if !known[winner] {
+			return fmt.Errorf("rules: %q in Beats is not listed in Moves", winner)
+		}
+		for loser := range losers {
+			if !known[loser] {
+				return fmt.Errorf("rules: %q in Beats is not listed in Moves", loser)
+			}
+		}
+	}
+
+	for i, a := range rs.Moves {
+		for _, b := range rs.Moves[i+1:] {
+			aBeatsB := rs.Beats[a][b] != ""
+			bBeatsA := rs.Beats[b][a] != ""
+			switch {
+			case aBeatsB && bBeatsA:
+				return fmt.Errorf("rules: %q and %q both beat each other", a, b)
+			case !aBeatsB && !bBeatsA:
+				return fmt.Errorf("rules: neither %q nor %q beats the other", a, b)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Counters returns every move in rs that beats move, in Moves order.
+// A nil result means move is undefeated by anything in rs (or isn't
+// one of rs's moves at all).
+func (rs RuleSet) Counters(move string) []string {
+	var winners []string
+	for _, m := range rs.Moves {
+		if _, ok := rs.Beats[m][move]; ok {
+			winners = append(winners, m)
+		}
+	}
+	return winners
+}
+
+// Outcome returns the score increments for the player who chose a and
+// the player who chose b: (1, -1) if a beats b under rs, (-1, 1) if b
+// beats a, or (0, 0) for a draw.
+func (rs RuleSet) Outcome(a, b string) (int, int) {
+	if a == b {
+		return 0, 0
+	}
+	if _, ok := rs.Beats[a][b]; ok {
+		return 1, -1
+	}
+	if _, ok := rs.Beats[b][a]; ok {
+		return -1, 1
+	}
+	return 0, 0
+}
+
+// ClassicRuleSet is the traditional three-move game.
+var ClassicRuleSet = RuleSet{
+	Name:  "classic",
+	Moves: []string{"rock", "paper", "scissors"},
+	Beats: map[string]map[string]string{
+		"rock":     {"scissors": "crushes"},
+		"paper":    {"rock": "covers"},
+		"scissors": {"paper": "cuts"},
+	},
+}
+
+// RPS5RuleSet is Rock-Paper-Scissors-Lizard-Spock, where every move
+// beats exactly two of the other four.
+var RPS5RuleSet = RuleSet{
+	Name:  "rps5",
+	Moves: []string{"rock", "paper", "scissors", "lizard", "spock"},
+	Beats: map[string]map[string]string{
+		"rock":     {"scissors": "crushes", "lizard": "crushes"},
+		"paper":    {"rock": "covers", "spock": "disproves"},
+		"scissors": {"paper": "cuts", "lizard": "decapitates"},
+		"lizard":   {"paper": "eats", "spock": "poisons"},
+		"spock":    {"scissors": "smashes", "rock": "vaporizes"},
+	},
+}
+
+// RPS101RuleSet is a 101-move variant built with newCyclicRuleSet,
+// since hand-curating the real RPS-101's 101*50 individually-named
+// pairings isn't practical here; every relationship is labeled with
+// the generic verb "beats" instead.
+var RPS101RuleSet = newCyclicRuleSet("rps101", rps101Moves(), "beats")
+
+// rps101Moves returns 101 synthetic move names ("move1".."move101") for
+// RPS101RuleSet.
+func rps101Moves() []string {
+	moves := make([]string, 101)
+	for i := range moves {
+		moves[i] = fmt.Sprintf("move%d", i+1)
+	}
+	return moves
+}
+
+// newCyclicRuleSet builds a RuleSet for an odd-length move list using
+// the standard generalized-RPS construction: each move beats the next
+// (n-1)/2 moves going clockwise through moves, and loses to the
+// previous (n-1)/2. Every relationship it creates is labeled verb.
+func newCyclicRuleSet(name string, moves []string, verb string) RuleSet {
+	n := len(moves)
+	half := (n - 1) / 2
+	beats := make(map[string]map[string]string, n)
+	for i, m := range moves {
+		losers := make(map[string]string, half)
+		for k := 1; k <= half; k++ {
+			losers[moves[(i+k)%n]] = verb
+		}
+		beats[m] = losers
+	}
+	return RuleSet{Name: name, Moves: moves, Beats: beats}
+}
+
+// LoadRuleSet resolves name to a validated RuleSet: the built-in
+// "classic", "rps5", or "rps101" (the empty string also means
+// "classic"), or a path to a JSON file describing a custom RuleSet.
+//
+// Only JSON is supported; YAML custom rule sets were scoped out of
+// this implementation (a YAML file can always be converted to the
+// equivalent JSON).
+func LoadRuleSet(name string) (RuleSet, error) {
+	var rs RuleSet
+
+	switch name {
+	case "", "classic":
+		rs = ClassicRuleSet
+	case "rps5":
+		rs = RPS5RuleSet
+	case "rps101":
+		rs = RPS101RuleSet
+	default:
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return RuleSet{}, fmt.Errorf("rules: failed to read rule set file %s: %w", name, err)
+		}
+		if err := json.Unmarshal(data, &rs); err != nil {
+			return RuleSet{}, fmt.Errorf("rules: failed to decode rule set file %s: %w", name, err)
+		}
+	}
+
+	if err := rs.Validate(); err != nil {
+		return RuleSet{}, fmt.Errorf("rules: invalid rule set %q: %w", name, err)
+	}
+	return rs, nil
+}
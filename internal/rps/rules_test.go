@@ -0,0 +1,79 @@
+// internal/rps/rules_test.go
+package rps
+
+import "testing"
+
+func TestRuleSetOutcome(t *testing.T) {
+	cases := []struct {
+		p1, p2       string
+		want1, want2 int
+	}{
+		{"rock", "scissors", 1, -1},
+		{"rock", "paper", -1, 1},
+		{"paper", "paper", 0, 0},
+	}
+
+	for _, c := range cases {
+		got1, got2 := ClassicRuleSet.Outcome(c.p1, c.p2)
+		if got1 != c.want1 || got2 != c.want2 {
+			t.Errorf("ClassicRuleSet.Outcome(%q, %q) = %d, %d; want %d, %d", c.p1, c.p2, got1, got2, c.want1, c.want2)
+		}
+	}
+}
+
+func TestBuiltinRuleSetsValidate(t *testing.T) {
+	for _, rs := range []RuleSet{ClassicRuleSet, RPS5RuleSet, RPS101RuleSet} {
+		if err := rs.Validate(); err != nil {
+			t.Errorf("%s.Validate() error = %v", rs.Name, err)
+		}
+	}
+}
+
+func TestRuleSetValidateRejectsMutualWin(t *testing.T) {
+	rs := RuleSet{
+		Moves: []string{"rock", "paper", "scissors"},
+		Beats: map[string]map[string]string{
+			"rock":  {"paper": "crushes"},
+			"paper": {"rock": "covers"},
+		},
+	}
+	if err := rs.Validate(); err == nil {
+		t.Error("Validate() error = nil; want an error for rock/paper beating each other")
+	}
+}
+
+func TestRuleSetValidateRejectsMissingWinner(t *testing.T) {
+	rs := RuleSet{
+		Moves: []string{"rock", "paper", "scissors"},
+		Beats: map[string]map[string]string{
+			"rock": {"scissors": "crushes"},
+		},
+	}
+	if err := rs.Validate(); err == nil {
+		t.Error("Validate() error = nil; want an error for paper/scissors having no winner")
+	}
+}
+
+func TestLoadRuleSetBuiltins(t *testing.T) {
+	cases := map[string]string{
+		"":        "classic",
+		"classic": "classic",
+		"rps5":    "rps5",
+		"rps101":  "rps101",
+	}
+	for input, wantName := range cases {
+		rs, err := LoadRuleSet(input)
+		if err != nil {
+			t.Fatalf("LoadRuleSet(%q) error = %v", input, err)
+		}
+		if rs.Name != wantName {
+			t.Errorf("LoadRuleSet(%q).Name = %q; want %q", input, rs.Name, wantName)
+		}
+	}
+}
+
+func TestLoadRuleSetMissingFile(t *testing.T) {
+	if _, err := LoadRuleSet("/no/such/rules.json"); err == nil {
+		t.Error("LoadRuleSet() error = nil; want an error for a missing file")
+	}
+}
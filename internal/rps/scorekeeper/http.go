@@ -0,0 +1,84 @@
+// internal/rps/scorekeeper/http.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: JSON HTTP endpoints exposing the scorekeeper's persisted
+//          game history, meant to run on a second port alongside the
+//          TCP game server.
+// **************************************************************
+
+package scorekeeper
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// NewHTTPHandler returns an http.Handler exposing:
+//
+//	GET /leaderboard             - every player's win/loss/draw record
+//	GET /player/{nick}/history    - a player's games, most recent first
+//	GET /game/{id}                - a single game by ID
+func NewHTTPHandler(store Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/leaderboard", leaderboardHandler(store))
+	mux.HandleFunc("/player/", playerHistoryHandler(store))
+	mux.HandleFunc("/game/", gameHandler(store))
+	return mux
+}
+
+func leaderboardHandler(store Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		entries, err := store.Leaderboard(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, entries)
+	}
+}
+
+func playerHistoryHandler(store Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nickname := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/player/"), "/history")
+		if nickname == "" || nickname == r.URL.Path {
+			http.Error(w, "expected /player/{nickname}/history", http.StatusBadRequest)
+			return
+		}
+
+		history, err := store.PlayerHistory(r.Context(), nickname)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, history)
+	}
+}
+
+func gameHandler(store Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/game/")
+		if id == "" {
+			http.Error(w, "expected /game/{id}", http.StatusBadRequest)
+			return
+		}
+
+		game, err := store.Game(r.Context(), id)
+		if errors.Is(err, ErrGameNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, game)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,105 @@
+// internal/rps/scorekeeper/memory.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: In-memory Store implementation that needs no database
+//          driver, used in tests.
+// **************************************************************
+
+package scorekeeper
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore is a Store backed by an in-process slice. It is not
+// persisted across restarts.
+type MemoryStore struct {
+	mu    sync.Mutex
+	games []GameRecord
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// RecordGame appends game to the in-memory history.
+func (m *MemoryStore) RecordGame(ctx context.Context, game GameRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.games = append(m.games, game)
+	return nil
+}
+
+// Leaderboard aggregates every recorded game into a per-player
+// win/loss/draw record, ordered by wins descending.
+func (m *MemoryStore) Leaderboard(ctx context.Context) ([]LeaderboardEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make(map[string]*LeaderboardEntry)
+	record := func(nickname string, won, lost, drew bool) {
+		e, ok := entries[nickname]
+		if !ok {
+			e = &LeaderboardEntry{Nickname: nickname}
+			entries[nickname] = e
+		}
+		switch {
+		case won:
+			e.Wins++
+		case lost:
+			e.Losses++
+		case drew:
+			e.Draws++
+		}
+	}
+
+	for _, g := range m.games {
+		draw := g.Winner == ""
+		record(g.Player1, g.Winner == g.Player1, g.Winner == g.Player2, draw)
+		record(g.Player2, g.Winner == g.Player2, g.Winner == g.Player1, draw)
+	}
+
+	out := make([]LeaderboardEntry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, *e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Wins > out[j].Wins })
+	return out, nil
+}
+
+// PlayerHistory returns every game nickname took part in, most recent
+// first.
+func (m *MemoryStore) PlayerHistory(ctx context.Context, nickname string) ([]GameRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var history []GameRecord
+	for i := len(m.games) - 1; i >= 0; i-- {
+		g := m.games[i]
+		if g.Player1 == nickname || g.Player2 == nickname {
+			history = append(history, g)
+		}
+	}
+	return history, nil
+}
+
+// Game returns the recorded game with the given ID.
+func (m *MemoryStore) Game(ctx context.Context, id string) (GameRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, g := range m.games {
+		if g.ID == id {
+			return g, nil
+		}
+	}
+	return GameRecord{}, ErrGameNotFound
+}
+
+// Close is a no-op for MemoryStore.
+func (m *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,64 @@
+// internal/rps/scorekeeper/memory_test.go
+package scorekeeper
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStoreLeaderboard(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	if err := store.RecordGame(ctx, GameRecord{ID: "1", Player1: "alice", Player2: "bob", Winner: "alice"}); err != nil {
+		t.Fatalf("RecordGame() error = %v", err)
+	}
+	if err := store.RecordGame(ctx, GameRecord{ID: "2", Player1: "alice", Player2: "bob", Winner: ""}); err != nil {
+		t.Fatalf("RecordGame() error = %v", err)
+	}
+
+	board, err := store.Leaderboard(ctx)
+	if err != nil {
+		t.Fatalf("Leaderboard() error = %v", err)
+	}
+
+	var alice, bob LeaderboardEntry
+	for _, e := range board {
+		switch e.Nickname {
+		case "alice":
+			alice = e
+		case "bob":
+			bob = e
+		}
+	}
+
+	if alice.Wins != 1 || alice.Draws != 1 || alice.Losses != 0 {
+		t.Errorf("alice = %+v; want 1 win, 1 draw, 0 losses", alice)
+	}
+	if bob.Losses != 1 || bob.Draws != 1 || bob.Wins != 0 {
+		t.Errorf("bob = %+v; want 1 loss, 1 draw, 0 wins", bob)
+	}
+}
+
+func TestMemoryStorePlayerHistoryMostRecentFirst(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	store.RecordGame(ctx, GameRecord{ID: "1", Player1: "alice", Player2: "bob"})
+	store.RecordGame(ctx, GameRecord{ID: "2", Player1: "alice", Player2: "carol"})
+
+	history, err := store.PlayerHistory(ctx, "alice")
+	if err != nil {
+		t.Fatalf("PlayerHistory() error = %v", err)
+	}
+	if len(history) != 2 || history[0].ID != "2" {
+		t.Errorf("PlayerHistory() = %+v; want game 2 first", history)
+	}
+}
+
+func TestMemoryStoreGameNotFound(t *testing.T) {
+	store := NewMemoryStore()
+	if _, err := store.Game(context.Background(), "missing"); err != ErrGameNotFound {
+		t.Errorf("Game() error = %v; want ErrGameNotFound", err)
+	}
+}
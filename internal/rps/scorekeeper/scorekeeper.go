@@ -0,0 +1,71 @@
+// internal/rps/scorekeeper/scorekeeper.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Asynchronously records game-ended events into a Store so
+//          the server's game loop never blocks on persistence.
+// **************************************************************
+
+package scorekeeper
+
+import (
+	"context"
+	"log"
+)
+
+// eventQueueSize bounds how many completed games can be buffered
+// waiting to be persisted before Publish starts dropping them.
+const eventQueueSize = 64
+
+// Scorekeeper publishes completed GameRecords to a Store on a
+// background goroutine, decoupling persistence from the game loop.
+type Scorekeeper struct {
+	store  Store
+	events chan GameRecord
+	done   chan struct{}
+}
+
+// New starts a Scorekeeper that persists completed games to store.
+// Call Close when the server shuts down to drain any buffered events.
+func New(store Store) *Scorekeeper {
+	sk := &Scorekeeper{
+		store:  store,
+		events: make(chan GameRecord, eventQueueSize),
+		done:   make(chan struct{}),
+	}
+	go sk.run()
+	return sk
+}
+
+// Publish queues a completed game to be persisted asynchronously. It
+// never blocks the caller on the database.
+func (sk *Scorekeeper) Publish(game GameRecord) {
+	select {
+	case sk.events <- game:
+	default:
+		log.Printf("scorekeeper: event queue full, dropping record for game %s", game.ID)
+	}
+}
+
+// Store returns the underlying Store, for wiring into an HTTP
+// handler.
+func (sk *Scorekeeper) Store() Store {
+	return sk.store
+}
+
+// Close stops accepting new events and waits for the background
+// worker to drain the queue.
+func (sk *Scorekeeper) Close() error {
+	close(sk.events)
+	<-sk.done
+	return sk.store.Close()
+}
+
+// run persists queued games until the event channel is closed.
+func (sk *Scorekeeper) run() {
+	defer close(sk.done)
+	for game := range sk.events {
+		if err := sk.store.RecordGame(context.Background(), game); err != nil {
+			log.Printf("scorekeeper: failed to record game %s: %v", game.ID, err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// internal/rps/scorekeeper/sqlite.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Default Store implementation, backed by SQLite via
+//          database/sql. Swap in a Postgres-backed Store by
+//          implementing the same interface against a different
+//          driver/DSN.
+// **************************************************************
+
+package scorekeeper
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS games (
+	id            TEXT PRIMARY KEY,
+	player1       TEXT NOT NULL,
+	player2       TEXT NOT NULL,
+	player1_score INTEGER NOT NULL,
+	player2_score INTEGER NOT NULL,
+	winner        TEXT NOT NULL DEFAULT '',
+	rounds        TEXT NOT NULL,
+	started_at    DATETIME NOT NULL,
+	ended_at      DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_games_player1 ON games(player1);
+CREATE INDEX IF NOT EXISTS idx_games_player2 ON games(player2);
+`
+
+// SQLiteStore is a Store backed by a SQLite database file, the
+// default persistent store used by the server.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) a SQLite database at
+// path and ensures its schema is up to date.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", path, err)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize sqlite schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// RecordGame persists game, JSON-encoding its per-round choices into
+// a single column.
+func (s *SQLiteStore) RecordGame(ctx context.Context, game GameRecord) error {
+	rounds, err := json.Marshal(game.Rounds)
+	if err != nil {
+		return fmt.Errorf("failed to encode rounds: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO games (id, player1, player2, player1_score, player2_score, winner, rounds, started_at, ended_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Player1, game.Player2, game.Player1Score, game.Player2Score, game.Winner, string(rounds), game.StartedAt, game.EndedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record game %s: %w", game.ID, err)
+	}
+	return nil
+}
+
+// Leaderboard aggregates every recorded game into a per-player
+// win/loss/draw record, ordered by wins descending.
+func (s *SQLiteStore) Leaderboard(ctx context.Context) ([]LeaderboardEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT player1, player2, winner FROM games`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query games for leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make(map[string]*LeaderboardEntry)
+	record := func(nickname string, won, lost, drew bool) {
+		e, ok := entries[nickname]
+		if !ok {
+			e = &LeaderboardEntry{Nickname: nickname}
+			entries[nickname] = e
+		}
+		switch {
+		case won:
+			e.Wins++
+		case lost:
+			e.Losses++
+		case drew:
+			e.Draws++
+		}
+	}
+
+	for rows.Next() {
+		var p1, p2, winner string
+		if err := rows.Scan(&p1, &p2, &winner); err != nil {
+			return nil, fmt.Errorf("failed to scan game row: %w", err)
+		}
+		draw := winner == ""
+		record(p1, winner == p1, winner == p2, draw)
+		record(p2, winner == p2, winner == p1, draw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read game rows: %w", err)
+	}
+
+	out := make([]LeaderboardEntry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, *e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Wins > out[j].Wins })
+	return out, nil
+}
+
+// PlayerHistory returns every game nickname took part in, most recent
+// first.
+func (s *SQLiteStore) PlayerHistory(ctx context.Context, nickname string) ([]GameRecord, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, player1, player2, player1_score, player2_score, winner, rounds, started_at, ended_at
+		 FROM games WHERE player1 = ? OR player2 = ? ORDER BY started_at DESC`,
+		nickname, nickname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history for %s: %w", nickname, err)
+	}
+	defer rows.Close()
+
+	var games []GameRecord
+	for rows.Next() {
+		g, err := scanGame(rows)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history rows: %w", err)
+	}
+	return games, nil
+}
+
+// Game returns the recorded game with the given ID.
+func (s *SQLiteStore) Game(ctx context.Context, id string) (GameRecord, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT id, player1, player2, player1_score, player2_score, winner, rounds, started_at, ended_at
+		 FROM games WHERE id = ?`, id)
+
+	g, err := scanGame(row)
+	if err == sql.ErrNoRows {
+		return GameRecord{}, ErrGameNotFound
+	}
+	if err != nil {
+		return GameRecord{}, fmt.Errorf("failed to load game %s: %w", id, err)
+	}
+	return g, nil
+}
+
+// Close releases the underlying database connection.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanGame decodes one games row, including its JSON-encoded rounds.
+func scanGame(row rowScanner) (GameRecord, error) {
+	var g GameRecord
+	var rounds string
+	var startedAt, endedAt time.Time
+
+	if err := row.Scan(&g.ID, &g.Player1, &g.Player2, &g.Player1Score, &g.Player2Score, &g.Winner, &rounds, &startedAt, &endedAt); err != nil {
+		return GameRecord{}, err
+	}
+
+	if err := json.Unmarshal([]byte(rounds), &g.Rounds); err != nil {
+		return GameRecord{}, fmt.Errorf("failed to decode rounds for game %s: %w", g.ID, err)
+	}
+	g.StartedAt = startedAt
+	g.EndedAt = endedAt
+	return g, nil
+}
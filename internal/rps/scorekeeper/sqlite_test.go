@@ -0,0 +1,109 @@
+// internal/rps/scorekeeper/sqlite_test.go
+package scorekeeper
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestSQLiteStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "rps_scores.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSQLiteStoreLeaderboardOrderedByWinsDescending(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	if err := store.RecordGame(ctx, GameRecord{ID: "1", Player1: "alice", Player2: "bob", Winner: "alice"}); err != nil {
+		t.Fatalf("RecordGame() error = %v", err)
+	}
+	if err := store.RecordGame(ctx, GameRecord{ID: "2", Player1: "alice", Player2: "carol", Winner: "alice"}); err != nil {
+		t.Fatalf("RecordGame() error = %v", err)
+	}
+	if err := store.RecordGame(ctx, GameRecord{ID: "3", Player1: "bob", Player2: "carol", Winner: "bob"}); err != nil {
+		t.Fatalf("RecordGame() error = %v", err)
+	}
+
+	board, err := store.Leaderboard(ctx)
+	if err != nil {
+		t.Fatalf("Leaderboard() error = %v", err)
+	}
+	if len(board) != 3 {
+		t.Fatalf("Leaderboard() returned %d entries; want 3", len(board))
+	}
+	for i := 1; i < len(board); i++ {
+		if board[i-1].Wins < board[i].Wins {
+			t.Fatalf("Leaderboard() = %+v; not ordered by wins descending", board)
+		}
+	}
+	if board[0].Nickname != "alice" || board[0].Wins != 2 {
+		t.Errorf("Leaderboard()[0] = %+v; want alice with 2 wins", board[0])
+	}
+}
+
+func TestSQLiteStorePlayerHistoryMostRecentFirst(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	if err := store.RecordGame(ctx, GameRecord{ID: "1", Player1: "alice", Player2: "bob", StartedAt: now}); err != nil {
+		t.Fatalf("RecordGame() error = %v", err)
+	}
+	if err := store.RecordGame(ctx, GameRecord{ID: "2", Player1: "alice", Player2: "carol", StartedAt: now.Add(time.Minute)}); err != nil {
+		t.Fatalf("RecordGame() error = %v", err)
+	}
+
+	history, err := store.PlayerHistory(ctx, "alice")
+	if err != nil {
+		t.Fatalf("PlayerHistory() error = %v", err)
+	}
+	if len(history) != 2 || history[0].ID != "2" {
+		t.Errorf("PlayerHistory() = %+v; want game 2 first", history)
+	}
+}
+
+func TestSQLiteStoreGameNotFound(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	if _, err := store.Game(context.Background(), "missing"); err != ErrGameNotFound {
+		t.Errorf("Game() error = %v; want ErrGameNotFound", err)
+	}
+}
+
+func TestSQLiteStoreGameRoundTrip(t *testing.T) {
+	store := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	want := GameRecord{
+		ID:           "1",
+		Player1:      "alice",
+		Player2:      "bob",
+		Player1Score: 2,
+		Player2Score: 1,
+		Winner:       "alice",
+		Rounds: []RoundChoice{
+			{Round: 0, Player1Choice: "rock", Player2Choice: "scissors"},
+		},
+	}
+	if err := store.RecordGame(ctx, want); err != nil {
+		t.Fatalf("RecordGame() error = %v", err)
+	}
+
+	got, err := store.Game(ctx, "1")
+	if err != nil {
+		t.Fatalf("Game() error = %v", err)
+	}
+	if got.Player1Score != want.Player1Score || got.Player2Score != want.Player2Score || got.Winner != want.Winner {
+		t.Errorf("Game() = %+v; want %+v", got, want)
+	}
+	if len(got.Rounds) != 1 || got.Rounds[0] != want.Rounds[0] {
+		t.Errorf("Game().Rounds = %+v; want %+v", got.Rounds, want.Rounds)
+	}
+}
@@ -0,0 +1,82 @@
+// internal/rps/scorekeeper/store.go
+// **************************************************************
+// Author: Tyler Laudenslager
+// Purpose: Defines the persistent record of a completed game and the
+//          Store interface backing it, so the concrete database
+//          (SQLite by default) can be swapped out.
+// **************************************************************
+
+package scorekeeper
+
+import (
+	"context"
+	"time"
+)
+
+// RoundChoice records both players' choices for a single round of a
+// finished game.
+type RoundChoice struct {
+	Round         int    `json:"round"`
+	Player1Choice string `json:"player1_choice"`
+	Player2Choice string `json:"player2_choice"`
+}
+
+// GameRecord is the full history of one completed game, as published
+// by the server when a game ends.
+type GameRecord struct {
+	ID           string        `json:"id"`
+	Player1      string        `json:"player1"`
+	Player2      string        `json:"player2"`
+	Player1Score int           `json:"player1_score"`
+	Player2Score int           `json:"player2_score"`
+	Winner       string        `json:"winner"` // nickname of the winner, or "" for a draw
+	Rounds       []RoundChoice `json:"rounds"`
+	StartedAt    time.Time     `json:"started_at"`
+	EndedAt      time.Time     `json:"ended_at"`
+}
+
+// Duration returns how long the game took to play.
+func (g GameRecord) Duration() time.Duration {
+	return g.EndedAt.Sub(g.StartedAt)
+}
+
+// LeaderboardEntry summarizes one player's record across all games
+// they have played.
+type LeaderboardEntry struct {
+	Nickname string `json:"nickname"`
+	Wins     int    `json:"wins"`
+	Losses   int    `json:"losses"`
+	Draws    int    `json:"draws"`
+}
+
+// Store persists completed games and answers queries over them. The
+// default implementation is backed by SQLite (see SQLiteStore); a
+// MemoryStore is provided for tests or environments without a
+// database driver, and the interface allows a Postgres-backed store
+// to be swapped in without touching callers.
+type Store interface {
+	// RecordGame persists a completed game.
+	RecordGame(ctx context.Context, game GameRecord) error
+
+	// Leaderboard returns every player's aggregate record, ordered by
+	// wins descending.
+	Leaderboard(ctx context.Context) ([]LeaderboardEntry, error)
+
+	// PlayerHistory returns every game a player took part in, most
+	// recent first.
+	PlayerHistory(ctx context.Context, nickname string) ([]GameRecord, error)
+
+	// Game returns a single game by ID.
+	Game(ctx context.Context, id string) (GameRecord, error)
+
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+// ErrGameNotFound is returned by Store.Game when no game with the
+// given ID has been recorded.
+var ErrGameNotFound = gameNotFoundError{}
+
+type gameNotFoundError struct{}
+
+func (gameNotFoundError) Error() string { return "scorekeeper: game not found" }